@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+	"github.com/d12frosted/gitpulse/internal/ui"
+	"github.com/muesli/termenv"
+)
+
+// doctor prints a checklist of common setup problems
+type doctor struct {
+	failed bool
+}
+
+func (d *doctor) pass(format string, args ...any) {
+	fmt.Printf("  ✓ %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) warn(hint, format string, args ...any) {
+	fmt.Printf("  ! %s\n      %s\n", fmt.Sprintf(format, args...), hint)
+}
+
+func (d *doctor) fail(hint, format string, args ...any) {
+	d.failed = true
+	fmt.Printf("  ✗ %s\n      %s\n", fmt.Sprintf(format, args...), hint)
+}
+
+// runDoctor checks git, the config file, each repo and the terminal, and
+// returns the process exit code
+func runDoctor() int {
+	var d doctor
+
+	fmt.Println("git")
+	cfg, cfgErr := config.Load()
+	if cfgErr == nil {
+		git.SetBinary(cfg.GitBinary())
+	} else {
+		git.SetBinary(new(config.Config).GitBinary())
+	}
+	if err := git.CheckBinary(); err != nil {
+		d.fail("Install git, or point git_path / GITPULSE_GIT at it", "%v", err)
+	} else if version, err := git.Version(); err != nil {
+		d.fail("Check that the git executable runs", "cannot get git version: %v", err)
+	} else {
+		d.pass("git %s", version)
+	}
+
+	fmt.Println("config")
+	var notFound *config.ConfigNotFoundError
+	switch {
+	case errors.As(cfgErr, &notFound):
+		d.fail("Run gitpulse to create one", "no config at %s", notFound.Path)
+	case cfgErr != nil:
+		d.fail("Fix the file with gitpulse --edit-config", "%v", cfgErr)
+	default:
+		d.pass("%s", config.ConfigPath())
+		if _, ok := ui.Themes[cfg.Theme]; cfg.Theme != "" && !ok {
+			d.warn(fmt.Sprintf("Falling back to %s", ui.DefaultTheme), "unknown theme %q", cfg.Theme)
+		}
+		if _, ok := ui.Spinners[cfg.Spinner]; cfg.Spinner != "" && !ok {
+			d.warn("Falling back to the default spinner", "unknown spinner %q", cfg.Spinner)
+		}
+	}
+
+	if cfg != nil {
+		fmt.Println("repos")
+		repos := cfg.RepoConfigs()
+		if len(repos) == 0 {
+			d.fail("Add paths to repos, or a scan root", "no repos configured")
+		}
+		for _, repo := range repos {
+			if repo.Remote {
+				d.pass("%s (remote only, not checked)", repo.Name)
+				continue
+			}
+			status := git.GetStatus(repo.Path, repo.Name)
+			if status.Error != nil {
+				d.fail("Fix or remove the entry for "+repo.Path, "%s: %v", repo.Name, status.Error)
+				continue
+			}
+			remotes, err := git.ListRemotes(repo.Path)
+			switch {
+			case err != nil:
+				d.fail("Check the repo with git remote -v", "%s: %v", repo.Name, err)
+			case len(remotes) == 0:
+				d.warn("Add one to fetch, sync and push (u in the TUI)", "%s: no remotes", repo.Name)
+			default:
+				d.pass("%s", repo.Name)
+			}
+		}
+	}
+
+	fmt.Println("terminal")
+	switch profile := lipgloss.ColorProfile(); profile {
+	case termenv.Ascii:
+		d.warn("Themes need a color terminal; check TERM and NO_COLOR", "no color support detected")
+	case termenv.ANSI:
+		d.warn("Use a 256-color or truecolor terminal for accurate themes", "only 16 colors supported")
+	default:
+		d.pass("%s colors", profile.Name())
+	}
+
+	if d.failed {
+		return 1
+	}
+	return 0
+}
@@ -1,38 +1,429 @@
 package config
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
 
 type Config struct {
-	Repos []string `toml:"repos"`
-	Theme string   `toml:"theme,omitempty"`
+	Repos   []RepoEntry `toml:"repos"`
+	Scan    []string    `toml:"scan,omitempty"`
+	Include []string    `toml:"include,omitempty"`
+	Theme   string      `toml:"theme,omitempty"`
+	Spinner string      `toml:"spinner,omitempty"`
+	Grouped *bool       `toml:"grouped,omitempty"`
+
+	ShowActivity   *bool  `toml:"show_activity,omitempty"`
+	ShowHelp       *bool  `toml:"show_help,omitempty"`
+	Watch          *bool  `toml:"watch,omitempty"`
+	ShowDiffStat   *bool  `toml:"show_diff_stat,omitempty"`
+	ShowTracking   *bool  `toml:"show_tracking,omitempty"`
+	FuzzyUpstream  *bool  `toml:"fuzzy_upstream,omitempty"`
+	AutoTrack      *bool  `toml:"auto_track_obvious,omitempty"`
+	BellOnComplete *bool  `toml:"bell_on_complete,omitempty"`
+	BellOnError    *bool  `toml:"bell_on_error,omitempty"`
+	LazyStatus     *bool  `toml:"lazy_status,omitempty"`
+	WrapNavigation *bool  `toml:"wrap_navigation,omitempty"`
+	CheckRemote    *bool  `toml:"check_remote,omitempty"`
+	FetchPrune     *bool  `toml:"fetch_prune,omitempty"`
+	FetchTags      *bool  `toml:"fetch_tags,omitempty"`
+	BulkFiltered   *bool  `toml:"bulk_respects_filter,omitempty"`
+	TerminalTitle  *bool  `toml:"set_terminal_title,omitempty"`
+	AbbreviateOver int    `toml:"abbreviate_over,omitempty"`
+	Columns        int    `toml:"columns,omitzero"`
+	EnterAction    string `toml:"enter_action,omitempty"`
+	GroupBy        string `toml:"group_by,omitempty"`
+	DefaultRemote  string `toml:"default_remote_name,omitempty"`
+	LogFile        string `toml:"log_file,omitempty"`
+	GitPath        string `toml:"git_path,omitempty"`
+	FooterTemplate string `toml:"footer_template,omitempty"`
+	AheadColor     string `toml:"ahead_color,omitempty"`
+	BehindColor    string `toml:"behind_color,omitempty"`
+	CommitsSince   string `toml:"commits_since,omitempty"`
+	StatusCommand  string `toml:"status_command,omitempty"`
+	PullStrategy   string `toml:"pull_strategy,omitempty"`
+
+	CommitSubjectWidth int `toml:"commit_subject_width,omitzero"`
+	MaxVisible         int `toml:"max_visible,omitzero"`
+
+	GroupOrder  []string `toml:"group_order,omitempty"`
+	StripPrefix []string `toml:"strip_prefix,omitempty"`
+	// Not omitempty: an empty list turns the confirmation off
+	Protected []string `toml:"protected_branches"`
+
+	FetchFreshness string `toml:"fetch_freshness,omitempty"`
+	UnpushedStale  string `toml:"unpushed_stale_after,omitempty"`
+
+	Glyphs Glyphs `toml:"glyphs,omitempty"`
+
+	// Repos from included files, in include order. Kept apart from Repos so
+	// saving the config doesn't copy them into it.
+	included []RepoEntry
+}
+
+// Glyphs are the symbols used for repo states, the cursor and marks. In the
+// config they override the defaults, e.g. with ASCII for fonts that lack
+// them; empty fields keep the default glyph.
+type Glyphs struct {
+	Synced     string `toml:"synced,omitempty"`
+	Ahead      string `toml:"ahead,omitempty"`
+	PushAhead  string `toml:"push_ahead,omitempty"` // Ahead of a separate push remote
+	Behind     string `toml:"behind,omitempty"`
+	NoUpstream string `toml:"no_upstream,omitempty"`
+	Error      string `toml:"error,omitempty"`
+	Remote     string `toml:"remote,omitempty"`    // Remote-only repo
+	News       string `toml:"news,omitempty"`      // Remote-only repo with new commits
+	Reachable  string `toml:"reachable,omitempty"` // Remote check result, colored
+	Cursor     string `toml:"cursor,omitempty"`
+	Mark       string `toml:"mark,omitempty"`
+}
+
+// PullStrategies are the ways sync can pull: rebase local commits onto the
+// upstream, merge it, or only fast-forward
+var PullStrategies = []string{"rebase", "merge", "ff-only"}
+
+// GroupCategories are the categories repos can be grouped into
+var GroupCategories = []string{"error", "behind", "diverged", "ahead", "dirty", "synced", "no-upstream"}
+
+// DefaultGroupOrder is the grouping order used for categories that
+// group_order leaves out. Dirty comes before behind and ahead, so a repo with
+// uncommitted changes is grouped as dirty whatever its upstream state.
+var DefaultGroupOrder = []string{"error", "dirty", "behind", "ahead", "synced", "no-upstream"}
+
+// DefaultProtectedBranches are the branches pushes are confirmed for unless
+// protected_branches says otherwise
+var DefaultProtectedBranches = []string{"main", "master"}
+
+// ProtectedBranches returns the branches that pushes are confirmed for
+func (c *Config) ProtectedBranches() []string {
+	if c.Protected == nil {
+		return DefaultProtectedBranches
+	}
+	return c.Protected
+}
+
+// StatusCommandArgs returns the status_command split into the program and its
+// arguments, or nil when none is set
+func (c *Config) StatusCommandArgs() []string {
+	args := strings.Fields(c.StatusCommand)
+	if len(args) > 0 {
+		args[0] = expandPath(args[0])
+	}
+	return args
+}
+
+// GroupedByDefault reports whether repos start grouped by status (default true)
+func (c *Config) GroupedByDefault() bool {
+	return boolOr(c.Grouped, true)
+}
+
+// GroupByHost reports whether grouping starts by remote host rather than by
+// status (default false)
+func (c *Config) GroupByHost() bool {
+	return c.GroupBy == "host"
+}
+
+// TerminalTitleEnabled reports whether the terminal title shows a status
+// summary (default false)
+func (c *Config) TerminalTitleEnabled() bool {
+	return boolOr(c.TerminalTitle, false)
+}
+
+// HelpVisible reports whether the help line starts visible (default true)
+func (c *Config) HelpVisible() bool {
+	return boolOr(c.ShowHelp, true)
+}
+
+// WatchEnabled reports whether repos refresh on changes to their .git dir (default false)
+func (c *Config) WatchEnabled() bool {
+	return boolOr(c.Watch, false)
+}
+
+// DiffStatEnabled reports whether dirty repos show uncommitted line counts (default false)
+func (c *Config) DiffStatEnabled() bool {
+	return boolOr(c.ShowDiffStat, false)
+}
+
+// TrackingVisible reports whether the branch column shows the tracked remote (default false)
+func (c *Config) TrackingVisible() bool {
+	return boolOr(c.ShowTracking, false)
+}
+
+// FetchPrunes reports whether fetches delete remote-tracking branches that
+// are gone upstream (default true)
+func (c *Config) FetchPrunes() bool {
+	return boolOr(c.FetchPrune, true)
+}
+
+// RemoteCheckEnabled reports whether refreshes check that upstream remotes
+// are reachable (default false, as it goes over the network)
+func (c *Config) RemoteCheckEnabled() bool {
+	return boolOr(c.CheckRemote, false)
+}
+
+// FuzzyUpstreamMatching reports whether the upstream modal also offers remote
+// branches that only share a suffix with the local branch (default false)
+func (c *Config) FuzzyUpstreamMatching() bool {
+	return boolOr(c.FuzzyUpstream, false)
+}
+
+// AutoTrackObvious reports whether a branch starts tracking the only remote
+// branch of the same name without asking (default true)
+func (c *Config) AutoTrackObvious() bool {
+	return boolOr(c.AutoTrack, true)
+}
+
+// RingOnComplete reports whether to ring the terminal bell when fetch/sync all
+// finishes (default false)
+func (c *Config) RingOnComplete() bool {
+	return boolOr(c.BellOnComplete, false)
+}
+
+// RingOnError reports whether to ring the terminal bell on new errors (default false)
+func (c *Config) RingOnError() bool {
+	return boolOr(c.BellOnError, false)
+}
+
+// LazyLoading reports whether statuses are only computed for repos on screen (default false)
+func (c *Config) LazyLoading() bool {
+	return boolOr(c.LazyStatus, false)
+}
+
+// WrapsNavigation reports whether moving past either end of the list wraps
+// around to the other (default false)
+func (c *Config) WrapsNavigation() bool {
+	return boolOr(c.WrapNavigation, false)
+}
+
+// BulkRespectsFilter reports whether bulk actions skip repos hidden by the
+// active filter (default true)
+func (c *Config) BulkRespectsFilter() bool {
+	return boolOr(c.BulkFiltered, true)
+}
+
+// ActivityEnabled reports whether the detail view shows recent commit activity (default true)
+func (c *Config) ActivityEnabled() bool {
+	return boolOr(c.ShowActivity, true)
+}
+
+// AbbreviateThreshold returns the count above which ahead/behind numbers are
+// abbreviated when abbreviation is toggled on (default 99)
+func (c *Config) AbbreviateThreshold() int {
+	if c.AbbreviateOver > 0 {
+		return c.AbbreviateOver
+	}
+	return 99
+}
+
+// ColumnCount returns the maximum number of columns the repo list is laid
+// out in (default 1)
+func (c *Config) ColumnCount() int {
+	if c.Columns > 1 {
+		return c.Columns
+	}
+	return 1
+}
+
+// LogPath returns the operation log path, resolving relative paths against
+// the config dir. Returns "" when logging is disabled.
+func (c *Config) LogPath() string {
+	if c.LogFile == "" {
+		return ""
+	}
+	path := expandPath(c.LogFile)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(ConfigDir(), path)
+	}
+	return path
+}
+
+// FreshFetchWindow returns how recently a repo must have been fetched for
+// fetch all to skip it. Zero means fetch all repos every time.
+func (c *Config) FreshFetchWindow() time.Duration {
+	d, _ := parseDuration(c.FetchFreshness)
+	return d
+}
+
+// UnpushedStaleAfter returns how old the last commit of a branch with unpushed
+// work may get before it is flagged. Zero disables the warning.
+func (c *Config) UnpushedStaleAfter() time.Duration {
+	d, _ := parseDuration(c.UnpushedStale)
+	return d
+}
+
+// parseDuration parses a Go duration, also accepting whole days such as "7d"
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// GitBinary returns the git executable to run: $GITPULSE_GIT, then git_path,
+// then git from PATH
+func (c *Config) GitBinary() string {
+	if env := os.Getenv("GITPULSE_GIT"); env != "" {
+		return expandPath(env)
+	}
+	if c.GitPath != "" {
+		return expandPath(c.GitPath)
+	}
+	return "git"
+}
+
+// DefaultRemoteName returns the name given to remotes added from the UI (default origin)
+func (c *Config) DefaultRemoteName() string {
+	if c.DefaultRemote != "" {
+		return c.DefaultRemote
+	}
+	return "origin"
+}
+
+// boolOr returns the value of an optional bool, or def when unset
+func boolOr(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
 }
 
 type RepoConfig struct {
-	Path string
-	Name string
+	Path          string
+	Link          string // Configured path, when Path is where its symlink resolves
+	Name          string
+	Remote        bool // Path is a remote URL with no local clone
+	Icon          string
+	Color         string
+	Alias         string // Key that jumps to the repo
+	ManualRefresh bool   // Only refreshed when acted on
+	Worktrees     bool   // Expand into one row per worktree
+	FetchTags     *bool  // Fetch with --tags or --no-tags; nil leaves it to git
+	PullStrategy  string // One of PullStrategies
+}
+
+// scpLikeURL matches scp-style git URLs such as git@github.com:user/repo.git
+var scpLikeURL = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+// stripNamePrefix trims the longest of prefixes from a display name, unless
+// nothing would be left of it
+func stripNamePrefix(name string, prefixes []string) string {
+	best := ""
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) && len(prefix) > len(best) && len(prefix) < len(name) {
+			best = prefix
+		}
+	}
+	return name[len(best):]
 }
 
+// IsRemoteURL reports whether a repo entry is a remote URL rather than a path
+func IsRemoteURL(entry string) bool {
+	return strings.Contains(entry, "://") || scpLikeURL.MatchString(entry)
+}
+
+// RepoConfigs returns the explicitly listed repos, in config order, followed
+// by repos from included files and repos discovered under the scan roots.
+// Included repos that are already listed are skipped, so the including
+// config's settings win.
 func (c *Config) RepoConfigs() []RepoConfig {
-	configs := make([]RepoConfig, 0, len(c.Repos))
-	for _, path := range c.Repos {
-		expanded := expandPath(path)
-		name := filepath.Base(expanded)
+	configs := make([]RepoConfig, 0, len(c.Repos)+len(c.included))
+	seen := make(map[string]bool)
+	for i, entry := range slices.Concat(c.Repos, c.included) {
+		path := entry.Path
+		key := path
+		if !IsRemoteURL(path) {
+			key = resolvePath(path)
+		}
+		if i >= len(c.Repos) && seen[key] {
+			continue
+		}
+		seen[key] = true
+		if IsRemoteURL(path) {
+			name := strings.TrimSuffix(path[strings.LastIndexAny(path, "/:")+1:], ".git")
+			if entry.Name != "" {
+				name = entry.Name
+			}
+			configs = append(configs, RepoConfig{Path: path, Name: name, Remote: true, Icon: entry.Icon, Color: entry.Color, Alias: entry.Alias, ManualRefresh: entry.ManualRefresh})
+			continue
+		}
+		// Named after the configured path, which may be a symlink
+		link := expandPath(path)
+		name := filepath.Base(link)
+		if entry.Name != "" {
+			name = entry.Name
+		}
+		if link == key {
+			link = ""
+		}
 		configs = append(configs, RepoConfig{
-			Path: expanded,
-			Name: name,
+			Path:          key,
+			Link:          link,
+			Name:          name,
+			Icon:          entry.Icon,
+			Color:         entry.Color,
+			Alias:         entry.Alias,
+			ManualRefresh: entry.ManualRefresh,
+			Worktrees:     entry.Worktrees,
+			FetchTags:     entry.FetchTags,
+			PullStrategy:  entry.PullStrategy,
 		})
 	}
+
+	for _, root := range c.Scan {
+		for _, repo := range scanRoot(root) {
+			if !seen[repo.Path] {
+				seen[repo.Path] = true
+				configs = append(configs, repo)
+			}
+		}
+	}
+
+	for i := range configs {
+		configs[i] = c.WithDefaults(configs[i])
+		configs[i].Name = stripNamePrefix(configs[i].Name, c.StripPrefix)
+	}
 	return configs
 }
 
+// WithDefaults returns repo with the settings it leaves unset taken from the
+// global config, e.g. fetch_tags and pull_strategy. Repos from scan roots or
+// --stdin carry none of their own.
+func (c *Config) WithDefaults(repo RepoConfig) RepoConfig {
+	if repo.FetchTags == nil {
+		repo.FetchTags = c.FetchTags
+	}
+	repo.PullStrategy = cmp.Or(repo.PullStrategy, c.PullStrategy, "rebase")
+	return repo
+}
+
+// resolvePath expands ~ and resolves symlinks, so a repo configured through
+// a symlink is fetched, pulled, pushed and watched at one real path
+func resolvePath(path string) string {
+	path = expandPath(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
 func expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
@@ -59,6 +450,20 @@ func ConfigPath() string {
 	return filepath.Join(ConfigDir(), "config.toml")
 }
 
+// EditCommand returns a command opening the config file in $VISUAL or
+// $EDITOR, falling back to vi. The editor may include arguments (code -w).
+func EditCommand() *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], ConfigPath())...)
+}
+
 func Load() (*Config, error) {
 	path := ConfigPath()
 	data, err := os.ReadFile(path)
@@ -73,10 +478,60 @@ func Load() (*Config, error) {
 	if err := toml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	if err := loadIncludes(&cfg, path, []string{filepath.Clean(path)}); err != nil {
+		return nil, err
+	}
+	for _, name := range cfg.GroupOrder {
+		if !slices.Contains(GroupCategories, name) {
+			return nil, fmt.Errorf("invalid group_order: unknown category %q (valid: %s)", name, strings.Join(GroupCategories, ", "))
+		}
+	}
+	if cfg.PullStrategy != "" && !slices.Contains(PullStrategies, cfg.PullStrategy) {
+		return nil, fmt.Errorf("invalid pull_strategy %q (valid: %s)", cfg.PullStrategy, strings.Join(PullStrategies, ", "))
+	}
+	for key, value := range map[string]string{
+		"fetch_freshness":      cfg.FetchFreshness,
+		"unpushed_stale_after": cfg.UnpushedStale,
+	} {
+		if _, err := parseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
 
 	return &cfg, nil
 }
 
+// loadIncludes reads the repos of the files cfg includes, recursively.
+// Relative include paths are resolved against the including file; stack
+// holds the files being loaded, to report include cycles.
+func loadIncludes(cfg *Config, path string, stack []string) error {
+	for _, include := range cfg.Include {
+		incPath := expandPath(include)
+		if !filepath.IsAbs(incPath) {
+			incPath = filepath.Join(filepath.Dir(path), incPath)
+		}
+		incPath = filepath.Clean(incPath)
+		if slices.Contains(stack, incPath) {
+			return fmt.Errorf("include cycle: %s", strings.Join(append(stack, incPath), " → "))
+		}
+
+		data, err := os.ReadFile(incPath)
+		if err != nil {
+			return fmt.Errorf("failed to read include: %w", err)
+		}
+		var sub Config
+		if err := toml.Unmarshal(data, &sub); err != nil {
+			return fmt.Errorf("failed to parse include %s: %w", incPath, err)
+		}
+		if err := loadIncludes(&sub, incPath, append(stack, incPath)); err != nil {
+			return err
+		}
+		cfg.included = append(cfg.included, sub.Repos...)
+		cfg.included = append(cfg.included, sub.included...)
+	}
+	return nil
+}
+
 func Save(cfg *Config) error {
 	dir := ConfigDir()
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -103,12 +558,161 @@ func ExampleConfig() string {
 # Color theme: dracula, nord, catppuccin, gruvbox, tokyonight, mono, jrpg-dark, jrpg-light
 theme = "dracula"
 
+# Override the theme's colors for ahead (↑) and behind (↓) counts
+# ahead_color = "#50fa7b"
+# behind_color = "#ff5555"
+
+# Spinner style: dot, line, minidot, jump, pulse, points, globe, moon, monkey, meter, hamburger, ellipsis
+spinner = "dot"
+
+# Group repos by status on startup (toggle at runtime with g)
+grouped = true
+
+# Order of the status groups; a repo goes into the first group it matches.
+# Categories: error, behind, diverged, ahead, dirty, synced, no-upstream.
+# Unlisted ones follow in the default order.
+# group_order = ["error", "dirty", "behind", "ahead", "synced", "no-upstream"]
+
+# Group by "status" (the categories above) or by the "host" of the origin
+# remote, e.g. github.com (switch at runtime with G)
+# group_by = "status"
+
+# Show the key help line at the bottom (toggle at runtime with ?)
+show_help = true
+
+# Set the terminal title to what needs attention, e.g. "gitpulse: 3 behind"
+set_terminal_title = false
+
+# Refresh a repo as soon as its refs change (e.g. after committing elsewhere)
+watch = false
+
+# Show uncommitted added/removed lines (e.g. +42 -7) next to dirty repos
+show_diff_stat = false
+
+# Show the remote a branch tracks next to it, e.g. main → origin
+show_tracking = false
+
+# Also offer remote branches like users/me/feature when setting the upstream of feature
+fuzzy_upstream = false
+
+# Track the remote branch of the same name without asking, when there's only one
+auto_track_obvious = true
+
+# Name of the remote added when a repo has none
+default_remote_name = "origin"
+
+# Ring the terminal bell when fetch/sync all finishes, or when a repo starts failing
+bell_on_complete = false
+bell_on_error = false
+
+# Only compute status for repos as they scroll into view (for very long lists)
+lazy_status = false
+
+# Check that upstream remotes answer (green ● reachable, red ● not), at most
+# every 5 minutes per remote. Off by default since it goes over the network
+check_remote = false
+
+# Moving up from the first repo jumps to the last one, and down from the last to the first
+wrap_navigation = false
+
+# With a status filter active (1-5), F, S, B and P only act on the visible repos
+bulk_respects_filter = true
+
+# Show a sparkline of commits per day over the last week in the detail view
+show_activity = true
+
+# Lay repos out in up to this many columns on wide terminals (left/right
+# moves between columns); fewer are used when the terminal is too narrow
+columns = 1
+
+# Ahead/behind counts above this are shown rounded (e.g. ↓300+) when toggled with #
+abbreviate_over = 99
+
+# What Enter does on the selected repo: detail, shell, sync, log
+enter_action = "detail"
+
+# Append fetch/sync/push results to this file (relative to the config dir)
+# log_file = "gitpulse.log"
+
+# Git executable to use instead of git from PATH ($GITPULSE_GIT takes precedence)
+# git_path = "/usr/local/bin/git"
+
+# Custom summary line below the list; placeholders: {repos} {behind} {ahead}
+# {dirty} {errors} {theme}. The active filter and notices are still appended.
+# footer_template = "{behind} behind · {ahead} ahead · {dirty} dirty · {theme}"
+
+# Show how many commits each repo got since this date, e.g. "midnight" (shown
+# as "3 today"), "1 week ago" or "monday"; any date git understands
+# commits_since = "midnight"
+
+# Show the first line a command prints for each repo in a column of its own,
+# e.g. a deploy state. It runs in the repo on every refresh, with the repo path
+# as its last argument, and is stopped after 5 seconds. Arguments are split on
+# spaces, without quoting, so wrap anything fancier in a script.
+# status_command = "~/bin/deploy-state --short"
+
+# Maximum width of the commit subject column (0 = use all remaining space)
+commit_subject_width = 0
+
+# Only list this many repos, the most actionable first when grouped, with a
+# note on how many more there are (0 = list all; + toggles the rest). Bulk
+# actions skip the repos left out.
+max_visible = 0
+
+# Delete remote-tracking branches that were deleted upstream when fetching
+fetch_prune = true
+
+# Fetch all tags (true) or none (false); unset leaves it to git, which fetches
+# tags pointing into the fetched history. Can be set per repo too.
+# fetch_tags = false
+
+# How sync pulls: "rebase" local commits onto the upstream, "merge" it, or
+# "ff-only", which fails rather than rebase or merge. Can be set per repo too.
+pull_strategy = "rebase"
+
+# Fetch all (F) skips repos fetched more recently than this, e.g. "5m"
+# fetch_freshness = "5m"
+
+# Flag branches with unpushed commits whose last commit is older than this
+# unpushed_stale_after = "7d"
+
+# Trim these prefixes from displayed repo names, e.g. acme-api shows as api
+# strip_prefix = ["acme-"]
+
+# Ask before pushing these branches directly; [] to never ask
+protected_branches = ["main", "master"]
+
 # Repository paths to monitor
+# Remote URLs are watched for new commits via ls-remote, without a local clone
+# Use a table to give a repo a display name, an icon, a name color or an alias
+# (press ' and then the alias to jump to the repo)
 repos = [
     "~/Developer/project1",
     "~/Developer/project2",
-    "~/work/important-repo",
+    { path = "~/work/important-repo", icon = "🚀", color = "#ff5555", alias = "w" },
+    # "https://github.com/user/upstream-project.git",
 ]
+
+# Also monitor any directory under these roots containing a .gitpulse file
+# scan = ["~/src"]
+
+# Also monitor the repos listed in these files (e.g. a shared team list).
+# Repos listed here take precedence over the same repo in an included file.
+# include = ["~/.config/gitpulse/company.toml"]
+
+# Replace the status glyphs, e.g. with ASCII if your font lacks them
+# [glyphs]
+# synced = "OK"
+# ahead = "+"
+# push_ahead = "^"
+# behind = "-"
+# no_upstream = "?"
+# error = "x"
+# remote = "@"
+# news = "*"
+# reachable = "o"
+# cursor = ">"
+# mark = "*"
 `
 }
 
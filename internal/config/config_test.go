@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepoConfigsResolvesSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "real")
+	link := filepath.Join(dir, "link")
+	if out, err := exec.Command("git", "init", "-q", target).CombinedOutput(); err != nil {
+		t.Skipf("git init: %v: %s", err, out)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	want, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{Repos: []RepoEntry{{Path: link}}}
+	repos := cfg.RepoConfigs()
+	if len(repos) != 1 {
+		t.Fatalf("got %d repos, want 1", len(repos))
+	}
+	if repos[0].Path != want {
+		t.Errorf("Path = %q, want resolved %q", repos[0].Path, want)
+	}
+	if repos[0].Link != link {
+		t.Errorf("Link = %q, want the configured %q", repos[0].Link, link)
+	}
+	if repos[0].Name != "link" {
+		t.Errorf("Name = %q, want the configured %q", repos[0].Name, "link")
+	}
+
+	// State recorded under the configured path still applies
+	reviewed := time.Now()
+	state := &State{Reviewed: map[string]time.Time{link: reviewed}}
+	state.MigratePaths(repos)
+	if at, ok := state.Reviewed[want]; !ok || !at.Equal(reviewed) {
+		t.Errorf("Reviewed[%q] = %v, %v; want %v", want, at, ok, reviewed)
+	}
+	if _, ok := state.Reviewed[link]; ok {
+		t.Errorf("Reviewed still has the configured path %q", link)
+	}
+}
+
+func TestRepoConfigsApplyGlobalDefaults(t *testing.T) {
+	root := t.TempDir()
+	scanned := filepath.Join(root, "scanned")
+	if err := os.Mkdir(scanned, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(scanned, MarkerFile), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	noTags, tags := false, true
+	cfg := &Config{
+		FetchTags:    &noTags,
+		PullStrategy: "ff-only",
+		Repos:        []RepoEntry{{Path: filepath.Join(root, "listed")}, {Path: filepath.Join(root, "own"), FetchTags: &tags}},
+		Scan:         []string{root},
+	}
+
+	want := map[string]bool{"listed": false, "own": true, "scanned": false}
+	repos := cfg.RepoConfigs()
+	if len(repos) != len(want) {
+		t.Fatalf("got %d repos, want %d", len(repos), len(want))
+	}
+	for _, repo := range repos {
+		if repo.FetchTags == nil || *repo.FetchTags != want[repo.Name] {
+			t.Errorf("%s: FetchTags = %v, want %v", repo.Name, repo.FetchTags, want[repo.Name])
+		}
+		if repo.PullStrategy != "ff-only" {
+			t.Errorf("%s: PullStrategy = %q, want ff-only", repo.Name, repo.PullStrategy)
+		}
+	}
+}
+
+// writeConfig writes files, by path relative to the config dir, and points
+// the config dir there
+func writeConfig(t *testing.T, files map[string]string) {
+	t.Helper()
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	dir := filepath.Join(xdg, "gitpulse")
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadMergesIncludes(t *testing.T) {
+	writeConfig(t, map[string]string{
+		"config.toml":      `repos = [{ path = "/src/a", name = "mine" }]` + "\ninclude = [\"team/team.toml\"]\n",
+		"team/team.toml":   `repos = [{ path = "/src/a", name = "theirs" }, "/src/b"]` + "\ninclude = [\"nested.toml\"]\n",
+		"team/nested.toml": `repos = ["/src/c"]`,
+	})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, repo := range cfg.RepoConfigs() {
+		names = append(names, repo.Name)
+	}
+	if want := []string{"mine", "b", "c"}; !slices.Equal(names, want) {
+		t.Errorf("repos = %v, want %v", names, want)
+	}
+	if len(cfg.Repos) != 1 {
+		t.Errorf("included repos leaked into Repos, which gets saved: %v", cfg.Repos)
+	}
+}
+
+func TestLoadReportsIncludeCycles(t *testing.T) {
+	writeConfig(t, map[string]string{
+		"config.toml": `include = ["a.toml"]`,
+		"a.toml":      `include = ["b.toml"]`,
+		"b.toml":      `include = ["a.toml"]`,
+	})
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Fatalf("Load = %v, want an include cycle error", err)
+	}
+}
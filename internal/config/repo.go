@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// RepoEntry is a repo listed in the config. It is written either as a plain
+// path string, or as a table when it carries per-repo settings:
+//
+//	repos = [
+//	    "~/src/plain",
+//	    { path = "~/src/fancy", icon = "🚀", color = "#ff0000" },
+//	]
+//
+// Tables can also be written as an array of tables:
+//
+//	[[repos]]
+//	path = "~/src/fancy"
+//	name = "fancy"
+type RepoEntry struct {
+	Path  string
+	Name  string // Overrides the name derived from the path
+	Icon  string // Shown before the repo name
+	Color string // Overrides the theme's repo name color
+	Alias string // Single key that jumps to the repo after '
+
+	// Skip the repo on startup and periodic refreshes
+	ManualRefresh bool
+	// Also show the repo's other worktrees as rows of their own
+	Worktrees bool
+	// Overrides the global fetch_tags
+	FetchTags *bool
+	// Overrides the global pull_strategy
+	PullStrategy string
+}
+
+// UnmarshalTOML decodes an entry from either a string or a table
+func (e *RepoEntry) UnmarshalTOML(data interface{}) error {
+	*e = RepoEntry{}
+	switch v := data.(type) {
+	case string:
+		e.Path = v
+		return nil
+	case map[string]interface{}:
+		fields := map[string]*string{
+			"path":          &e.Path,
+			"name":          &e.Name,
+			"icon":          &e.Icon,
+			"color":         &e.Color,
+			"alias":         &e.Alias,
+			"pull_strategy": &e.PullStrategy,
+		}
+		// Sorted so the first bad key reported is stable
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		// fetch_tags keeps a pointer, since unset differs from false there
+		flags := map[string]func(b bool){
+			"manual_refresh": func(b bool) { e.ManualRefresh = b },
+			"worktrees":      func(b bool) { e.Worktrees = b },
+			"fetch_tags":     func(b bool) { e.FetchTags = &b },
+		}
+		for _, key := range keys {
+			if set, ok := flags[key]; ok {
+				b, ok := v[key].(bool)
+				if !ok {
+					return fmt.Errorf("repo %s must be a boolean", key)
+				}
+				set(b)
+				continue
+			}
+			field, ok := fields[key]
+			if !ok {
+				return fmt.Errorf("unknown repo key %q", key)
+			}
+			s, ok := v[key].(string)
+			if !ok {
+				return fmt.Errorf("repo %s must be a string", key)
+			}
+			*field = s
+		}
+		if e.Path == "" {
+			return fmt.Errorf("repo entry is missing a path")
+		}
+		if e.Alias != "" && utf8.RuneCountInString(e.Alias) != 1 {
+			return fmt.Errorf("repo alias %q must be a single character", e.Alias)
+		}
+		if e.PullStrategy != "" && !slices.Contains(PullStrategies, e.PullStrategy) {
+			return fmt.Errorf("repo pull_strategy %q must be one of %s", e.PullStrategy, strings.Join(PullStrategies, ", "))
+		}
+		return nil
+	}
+	return fmt.Errorf("repo entry must be a path or a table, got %T", data)
+}
+
+// MarshalTOML encodes plain entries as a string and others as an inline table
+func (e RepoEntry) MarshalTOML() ([]byte, error) {
+	if e.Name == "" && e.Icon == "" && e.Color == "" && e.Alias == "" && !e.ManualRefresh && !e.Worktrees && e.FetchTags == nil && e.PullStrategy == "" {
+		return []byte(quote(e.Path)), nil
+	}
+
+	parts := []string{"path = " + quote(e.Path)}
+	if e.Name != "" {
+		parts = append(parts, "name = "+quote(e.Name))
+	}
+	if e.Icon != "" {
+		parts = append(parts, "icon = "+quote(e.Icon))
+	}
+	if e.Color != "" {
+		parts = append(parts, "color = "+quote(e.Color))
+	}
+	if e.Alias != "" {
+		parts = append(parts, "alias = "+quote(e.Alias))
+	}
+	if e.ManualRefresh {
+		parts = append(parts, "manual_refresh = true")
+	}
+	if e.Worktrees {
+		parts = append(parts, "worktrees = true")
+	}
+	if e.FetchTags != nil {
+		parts = append(parts, fmt.Sprintf("fetch_tags = %t", *e.FetchTags))
+	}
+	if e.PullStrategy != "" {
+		parts = append(parts, "pull_strategy = "+quote(e.PullStrategy))
+	}
+	return []byte("{ " + strings.Join(parts, ", ") + " }"), nil
+}
+
+// quote returns s as a TOML basic string
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, "\\u%04X", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestRepoEntryRoundTrip(t *testing.T) {
+	fetchTags := false
+	tests := []struct {
+		name  string
+		entry RepoEntry
+		want  string
+	}{
+		{"string", RepoEntry{Path: "~/src/plain"}, `"~/src/plain"`},
+		{"table", RepoEntry{
+			Path:          "~/src/fancy",
+			Name:          "fancy \"one\"",
+			Icon:          "🚀",
+			Alias:         "f",
+			ManualRefresh: true,
+			FetchTags:     &fetchTags,
+			PullStrategy:  "ff-only",
+		}, `{ path = "~/src/fancy", name = "fancy \"one\"", icon = "🚀", alias = "f", manual_refresh = true, fetch_tags = false, pull_strategy = "ff-only" }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.entry.MarshalTOML()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("MarshalTOML = %s, want %s", data, tt.want)
+			}
+			var doc struct{ Repos []RepoEntry }
+			if _, err := toml.Decode("repos = ["+string(data)+"]", &doc); err != nil {
+				t.Fatal(err)
+			}
+			if len(doc.Repos) != 1 || !reflect.DeepEqual(doc.Repos[0], tt.entry) {
+				t.Errorf("decoded %+v, want %+v", doc.Repos, tt.entry)
+			}
+		})
+	}
+}
+
+func TestRepoEntryUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`{ path = "~/src/x", worktrees = "yes" }`, "repo worktrees must be a boolean"},
+		{`{ path = "~/src/x", fetch_tags = 1 }`, "repo fetch_tags must be a boolean"},
+		{`{ path = "~/src/x", colour = "red" }`, `unknown repo key "colour"`},
+	}
+	for _, tt := range tests {
+		var doc struct{ Repos []RepoEntry }
+		_, err := toml.Decode("repos = ["+tt.input+"]", &doc)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("decoding %s: got error %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// MarkerFile is the name of the file that opts a directory into monitoring
+// when it is found under one of the configured scan roots
+const MarkerFile = ".gitpulse"
+
+// maxScanDepth limits how deep below a scan root repos are searched for
+const maxScanDepth = 4
+
+// Marker holds optional per-repo settings read from a marker file
+type Marker struct {
+	Name string `toml:"name"`
+}
+
+// scanRoot returns repos under root that contain a marker file
+// Unreadable directories and malformed markers are skipped
+func scanRoot(root string) []RepoConfig {
+	root = resolvePath(root)
+
+	var configs []RepoConfig
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if depth := strings.Count(strings.TrimPrefix(path, root), string(filepath.Separator)); depth > maxScanDepth {
+			return filepath.SkipDir
+		}
+
+		if _, err := os.Stat(filepath.Join(path, MarkerFile)); err == nil {
+			if marker, err := readMarker(path); err == nil {
+				configs = append(configs, RepoConfig{
+					Path: path,
+					Name: marker.Name,
+				})
+			}
+			return filepath.SkipDir
+		}
+
+		// Don't descend into repos that haven't opted in
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil && path != root {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	return configs
+}
+
+// readMarker parses the marker file in dir, filling in defaults
+func readMarker(dir string) (Marker, error) {
+	var marker Marker
+	if _, err := toml.DecodeFile(filepath.Join(dir, MarkerFile), &marker); err != nil {
+		return Marker{}, err
+	}
+	if marker.Name == "" {
+		marker.Name = filepath.Base(dir)
+	}
+	return marker, nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanRootSkipsMalformedMarkers(t *testing.T) {
+	root := t.TempDir()
+	markers := map[string]string{
+		"plain":  "",
+		"named":  `name = "fancy"`,
+		"broken": `name = "unterminated`,
+	}
+	for dir, content := range markers {
+		if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, MarkerFile), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, repo := range scanRoot(root) {
+		names[repo.Name] = true
+	}
+	if len(names) != 2 || !names["plain"] || !names["fancy"] {
+		t.Errorf("scanned repos %v, want plain and fancy", names)
+	}
+}
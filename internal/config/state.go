@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// State is gitpulse-local data persisted between runs. Unlike Config it is
+// written by gitpulse itself and not meant to be edited by hand.
+type State struct {
+	// RemoteHeads maps remote-only repo URLs to the last seen HEAD commit
+	RemoteHeads map[string]string `toml:"remote_heads,omitempty"`
+	// Reviewed maps repo paths to when they were last marked as reviewed
+	Reviewed map[string]time.Time `toml:"reviewed,omitempty"`
+}
+
+func StatePath() string {
+	return filepath.Join(ConfigDir(), "state.toml")
+}
+
+// LoadState reads the state file, returning empty state if it doesn't exist
+func LoadState() (*State, error) {
+	state := &State{RemoteHeads: make(map[string]string), Reviewed: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(StatePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return state, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	if err := toml.Unmarshal(data, state); err != nil {
+		return state, fmt.Errorf("failed to parse state: %w", err)
+	}
+	if state.RemoteHeads == nil {
+		state.RemoteHeads = make(map[string]string)
+	}
+	if state.Reviewed == nil {
+		state.Reviewed = make(map[string]time.Time)
+	}
+
+	return state, nil
+}
+
+// MigratePaths moves state recorded under the configured path of symlinked
+// repos, as written before repo paths were resolved, to their real path
+func (s *State) MigratePaths(repos []RepoConfig) {
+	for _, repo := range repos {
+		if repo.Link == "" {
+			continue
+		}
+		if at, ok := s.Reviewed[repo.Link]; ok {
+			if _, exists := s.Reviewed[repo.Path]; !exists {
+				s.Reviewed[repo.Path] = at
+			}
+			delete(s.Reviewed, repo.Link)
+		}
+	}
+}
+
+func SaveState(state *State) error {
+	if err := os.MkdirAll(ConfigDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	f, err := os.Create(StatePath())
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	defer f.Close()
+
+	if err := toml.NewEncoder(f).Encode(state); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package git
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,6 +10,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type RepoStatus struct {
@@ -21,27 +24,79 @@ type RepoStatus struct {
 	Dirty         bool
 	HasUpstream   bool
 	Error         error
-	Fetching      bool
-	Rebasing      bool
-	Pushing       bool
-	LastMessage   string
 	CommitSubject string
 	CommitAge     string
-	CommitTime    int64 // Unix timestamp for sorting
+	CommitTime    int64     // Unix timestamp for sorting
+	HeadHash      string    // Full hash of HEAD
+	Insertions    int       // Uncommitted added lines, if requested via DiffStat
+	Deletions     int       // Uncommitted removed lines, if requested via DiffStat
+	RecentCommits int       // Commits on HEAD since a date, if requested via CommitCountSince
+	PushRef       string    // Where HEAD is pushed, when that differs from Upstream (triangular workflow)
+	PushAhead     int       // Commits not yet on PushRef
+	Sparse        bool      // Sparse checkout is enabled
+	PartialClone  bool      // Objects are fetched lazily from a promisor remote
+	Shallow       bool      // History is truncated (a shallow clone)
+	Submodules    int       // Submodules that are modified or moved
+	Locked        string    // Lock file held by another git process, e.g. index.lock
+	LastFetchTime time.Time // Zero if the repo was never fetched
+	Detached      bool      // HEAD is not on a branch
+	Tag           string    // Tag HEAD exactly matches, if detached
+	DefaultBranch string    // The remote's default branch (e.g. main), if known
+	RemoteHost    string    // Host of the origin remote, if requested via RemoteHost
+	Custom        string    // Output of the user's status_command, if configured
+	// Whether the upstream remote answered, if checked via CheckRemote
+	RemoteReachable Reachability
+
+	// Remote-only repos (no local clone)
+	RemoteOnly    bool
+	RemoteHead    string // Current HEAD commit of the remote
+	RemoteChanged bool   // HEAD moved since it was last seen
 }
 
 func (s *RepoStatus) IsSynced() bool {
-	return s.HasUpstream && s.Ahead == 0 && s.Behind == 0 && s.Error == nil
+	return s.HasUpstream && s.Unpushed() == 0 && s.Behind == 0 && s.Error == nil
 }
 
 func (s *RepoStatus) NeedsPush() bool {
-	return s.HasUpstream && s.Ahead > 0 && s.Error == nil
+	return s.HasUpstream && s.Unpushed() > 0 && s.Error == nil
+}
+
+// IsDiverged reports whether the branch has both local and upstream commits
+func (s *RepoStatus) IsDiverged() bool {
+	return s.HasUpstream && s.Ahead > 0 && s.Behind > 0
+}
+
+// Unpushed returns the number of commits a push would publish: commits ahead
+// of the push destination in triangular workflows, of the upstream otherwise
+func (s *RepoStatus) Unpushed() int {
+	if s.PushRef != "" {
+		return s.PushAhead
+	}
+	return s.Ahead
 }
 
 func (s *RepoStatus) NeedsPull() bool {
 	return s.HasUpstream && s.Behind > 0 && s.Error == nil
 }
 
+// HeadLabel returns the branch name, or for a detached HEAD the tag it points
+// at (e.g. @v1.2.3), falling back to the short commit hash
+func (s *RepoStatus) HeadLabel() string {
+	if !s.Detached {
+		return s.Branch
+	}
+	if s.Tag != "" {
+		return "@" + s.Tag
+	}
+	if len(s.HeadHash) > 7 {
+		return s.HeadHash[:7]
+	}
+	if s.HeadHash != "" {
+		return s.HeadHash
+	}
+	return s.Branch
+}
+
 func GetStatus(path, name string) *RepoStatus {
 	status := &RepoStatus{
 		Path: path,
@@ -78,20 +133,51 @@ func GetStatus(path, name string) *RepoStatus {
 	}
 	status.Branch = strings.TrimSpace(branch)
 
-	// Check for uncommitted changes
-	porcelain, _ := runGit(path, "status", "--porcelain")
+	status.DefaultBranch, _ = DefaultBranch(path, branchRemote(path, status.Branch))
+
+	// FETCH_HEAD is rewritten on every fetch, so its mtime is the last fetch time
+	if info, err := os.Stat(filepath.Join(gitDir, "FETCH_HEAD")); err == nil {
+		status.LastFetchTime = info.ModTime()
+	}
+
+	// Sparse, partial and shallow clones explain an incomplete looking working
+	// tree or history
+	config, _ := runGit(path, "config", "--get-regexp", `^(core\.sparsecheckout|extensions\.partialclone|remote\..*\.promisor)$`)
+	for _, line := range strings.Split(strings.TrimSpace(config), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch {
+		case key == "core.sparsecheckout":
+			status.Sparse = value == "true"
+		case key == "extensions.partialclone", strings.HasSuffix(key, ".promisor") && value == "true":
+			status.PartialClone = true
+		}
+	}
+	status.Shallow = IsShallow(path)
+	status.Locked = HeldLock(path)
+
+	// Check for uncommitted changes, submodules among them
+	porcelain, _ := runGit(path, "status", "--porcelain=v2")
 	status.Dirty = strings.TrimSpace(porcelain) != ""
+	status.Submodules = countSubmodules(porcelain)
 
 	// Get last commit info
-	commitInfo, err := runGit(path, "log", "-1", "--format=%s|%cr|%ct")
+	// Subject goes last since it may itself contain the separator
+	commitInfo, err := runGit(path, "log", "-1", "--format=%H|%ct|%cr|%s")
 	if err == nil {
-		parts := strings.SplitN(strings.TrimSpace(commitInfo), "|", 3)
-		if len(parts) >= 2 {
-			status.CommitSubject = parts[0]
-			status.CommitAge = parts[1]
+		parts := strings.SplitN(strings.TrimSpace(commitInfo), "|", 4)
+		if len(parts) == 4 {
+			status.HeadHash = parts[0]
+			status.CommitTime, _ = strconv.ParseInt(parts[1], 10, 64)
+			status.CommitAge = parts[2]
+			status.CommitSubject = parts[3]
 		}
-		if len(parts) == 3 {
-			status.CommitTime, _ = strconv.ParseInt(parts[2], 10, 64)
+	}
+
+	// Pinned checkouts (e.g. deployments) are often detached at a tag
+	if status.Branch == "HEAD" {
+		status.Detached = true
+		if tag, err := runGit(path, "describe", "--exact-match", "--tags", "HEAD"); err == nil {
+			status.Tag = strings.TrimSpace(tag)
 		}
 	}
 
@@ -117,28 +203,202 @@ func GetStatus(path, name string) *RepoStatus {
 		status.Behind, _ = strconv.Atoi(parts[1])
 	}
 
+	// In triangular workflows pushes go elsewhere (e.g. a fork), so count
+	// unpushed commits against that instead
+	push, err := runGit(path, "rev-parse", "--abbrev-ref", "@{push}")
+	if err == nil && strings.TrimSpace(push) != status.Upstream {
+		count, err := runGit(path, "rev-list", "--count", "@{push}..HEAD")
+		if err == nil {
+			status.PushRef = strings.TrimSpace(push)
+			status.PushAhead, _ = strconv.Atoi(strings.TrimSpace(count))
+		}
+	}
+
 	return status
 }
 
-func Fetch(path string) error {
-	_, err := runGit(path, "fetch", "--prune")
+// branchRemote returns the remote a branch tracks, or origin when it tracks none
+func branchRemote(path, branch string) string {
+	if r, err := runGit(path, "config", "--get", "branch."+branch+".remote"); err == nil && strings.TrimSpace(r) != "." {
+		return strings.TrimSpace(r)
+	}
+	return "origin"
+}
+
+// DefaultBranch returns the default branch of remote as recorded by its HEAD
+// (e.g. main), falling back to a local main or master when the remote HEAD is
+// unknown
+func DefaultBranch(path, remote string) (string, error) {
+	if ref, err := runGit(path, "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD"); err == nil {
+		return strings.TrimPrefix(strings.TrimSpace(ref), remote+"/"), nil
+	}
+	for _, name := range []string{"main", "master"} {
+		if _, err := runGit(path, "rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no default branch for %s", remote)
+}
+
+// GetRemoteStatus returns the status of a remote-only repo by resolving the
+// remote's HEAD with ls-remote and comparing it to the last seen commit
+func GetRemoteStatus(url, name, seenHead string) *RepoStatus {
+	status := &RepoStatus{
+		Path:       url,
+		Name:       name,
+		RemoteOnly: true,
+	}
+
+	head, err := RemoteHead(url)
+	if err != nil {
+		status.Error = err
+		return status
+	}
+	status.RemoteHead = head
+	status.RemoteChanged = seenHead != "" && seenHead != head
+
+	return status
+}
+
+// DiffStat returns the number of inserted and deleted lines in staged and
+// unstaged changes combined. Untracked files are not counted.
+func DiffStat(path string) (insertions, deletions int, err error) {
+	output, err := runGit(path, "diff", "HEAD", "--shortstat")
+	if err != nil {
+		return 0, 0, err
+	}
+	// e.g. " 3 files changed, 42 insertions(+), 7 deletions(-)"
+	for _, part := range strings.Split(output, ",") {
+		fields := strings.Fields(part)
+		if len(fields) < 2 {
+			continue
+		}
+		n, _ := strconv.Atoi(fields[0])
+		switch {
+		case strings.HasPrefix(fields[1], "insertion"):
+			insertions = n
+		case strings.HasPrefix(fields[1], "deletion"):
+			deletions = n
+		}
+	}
+	return insertions, deletions, nil
+}
+
+// StatusVerbose returns the output of a plain, long format `git status`
+func StatusVerbose(path string) (string, error) {
+	return runGit(path, "-c", "color.status=never", "status", "--long")
+}
+
+// DiffStatUpstream returns `git diff --stat` of what a pull would bring in
+func DiffStatUpstream(path string) (string, error) {
+	return runGit(path, "diff", "--stat", "HEAD...@{upstream}")
+}
+
+// RemoteHead returns the commit the remote's HEAD points to. Like
+// CheckRemote it gives up after reachTimeout and never prompts for
+// credentials.
+func RemoteHead(url string) (string, error) {
+	output, err := lsRemote("", url, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("remote has no HEAD")
+	}
+	return fields[0], nil
+}
+
+// Fetch fetches all remotes, optionally pruning remote-tracking branches
+// that were deleted upstream. tags forces fetching all tags (true) or none
+// (false); nil leaves it to git.
+func Fetch(path string, prune bool, tags *bool) error {
+	if err := checkUnlocked(path, fetchLocks); err != nil {
+		return err
+	}
+	args := []string{"fetch"}
+	if prune {
+		args = append(args, "--prune")
+	}
+	if tags != nil {
+		if *tags {
+			args = append(args, "--tags")
+		} else {
+			args = append(args, "--no-tags")
+		}
+	}
+	_, err := runGit(path, args...)
 	return err
 }
 
-func Pull(path string) error {
-	_, err := runGit(path, "pull", "--rebase", "--autostash")
+// Pull pulls the current branch with strategy: "rebase", "merge", or
+// "ff-only". Local changes are stashed around it whatever the strategy.
+func Pull(path, strategy string) error {
+	if err := checkUnlocked(path, allLocks); err != nil {
+		return err
+	}
+	mode := "--rebase"
+	switch strategy {
+	case "merge":
+		mode = "--no-rebase"
+	case "ff-only":
+		mode = "--ff-only"
+	}
+	_, err := runGit(path, "pull", mode, "--autostash")
+	if err != nil && strategy == "ff-only" && strings.Contains(err.Error(), "Not possible to fast-forward") {
+		return fmt.Errorf("not fast-forwardable; needs rebase/merge")
+	}
+	return err
+}
+
+// Head returns the full hash of HEAD
+func Head(path string) (string, error) {
+	output, err := runGit(path, "rev-parse", "HEAD")
+	return strings.TrimSpace(output), err
+}
+
+// CommitSummary returns the short hash and subject of rev
+func CommitSummary(path, rev string) (string, error) {
+	output, err := runGit(path, "log", "-1", "--format=%h %s", rev)
+	return strings.TrimSpace(output), err
+}
+
+// ResetHard hard resets the repo to hash, e.g. to undo a pull. Uncommitted
+// changes, including ones restored by autostash, are lost.
+func ResetHard(path, hash string) error {
+	if err := checkUnlocked(path, treeLocks); err != nil {
+		return err
+	}
+	_, err := runGit(path, "reset", "--hard", hash)
+	return err
+}
+
+// Maintenance runs the housekeeping tasks git considers due (gc, repacking,
+// commit-graph), falling back to gc on git versions before maintenance
+func Maintenance(path string) error {
+	if err := checkUnlocked(path, allLocks); err != nil {
+		return err
+	}
+	_, err := runGit(path, "maintenance", "run", "--auto")
+	if err != nil && strings.Contains(err.Error(), "is not a git command") {
+		_, err = runGit(path, "gc", "--auto")
+	}
 	return err
 }
 
 func Push(path string) error {
+	if err := checkUnlocked(path, refLocks); err != nil {
+		return err
+	}
 	_, err := runGit(path, "push")
-	return err
+	return classifyPushError(path, err)
 }
 
 // Remote represents a git remote
 type Remote struct {
-	Name string
-	URL  string
+	Name    string
+	URL     string
+	PushURL string // Where pushes go; the same as URL unless set apart
 }
 
 // ListRemotes returns all configured remotes for a repository
@@ -147,26 +407,50 @@ func ListRemotes(path string) ([]Remote, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseRemotes(output), nil
+}
 
-	remoteMap := make(map[string]string)
+// parseRemotes parses `git remote -v` output. Each line is the remote name, a
+// tab, then the URL followed by " (fetch)" or " (push)"; the URL may itself
+// contain spaces. A remote without a push line pushes to its fetch URL.
+func parseRemotes(output string) []Remote {
+	remoteMap := make(map[string]*Remote)
 	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
-		if line == "" {
+		name, rest, ok := strings.Cut(line, "\t")
+		if !ok || name == "" {
 			continue
 		}
-		parts := strings.Fields(line)
-		if len(parts) >= 2 {
-			// Only take fetch URLs (avoid duplicates from push)
-			if len(parts) >= 3 && strings.Contains(parts[2], "fetch") {
-				remoteMap[parts[0]] = parts[1]
-			} else if _, exists := remoteMap[parts[0]]; !exists {
-				remoteMap[parts[0]] = parts[1]
+		url, kind := rest, ""
+		if i := strings.LastIndex(rest, " ("); i >= 0 && strings.HasSuffix(rest, ")") {
+			url, kind = rest[:i], rest[i+2:len(rest)-1]
+		}
+		url = strings.TrimSpace(url)
+		remote, exists := remoteMap[name]
+		if !exists {
+			remote = &Remote{Name: name}
+			remoteMap[name] = remote
+		}
+		switch kind {
+		case "push":
+			remote.PushURL = url
+		case "fetch":
+			remote.URL = url
+		default:
+			if remote.URL == "" {
+				remote.URL = url
 			}
 		}
 	}
 
 	var remotes []Remote
-	for name, url := range remoteMap {
-		remotes = append(remotes, Remote{Name: name, URL: url})
+	for _, remote := range remoteMap {
+		if remote.URL == "" {
+			remote.URL = remote.PushURL
+		}
+		if remote.PushURL == "" {
+			remote.PushURL = remote.URL
+		}
+		remotes = append(remotes, *remote)
 	}
 
 	// Sort by name for consistent ordering
@@ -181,7 +465,7 @@ func ListRemotes(path string) ([]Remote, error) {
 		return remotes[i].Name < remotes[j].Name
 	})
 
-	return remotes, nil
+	return remotes
 }
 
 // RemoteBranch represents a branch on a remote
@@ -219,28 +503,418 @@ func ListRemoteBranches(path, branchName string) ([]RemoteBranch, error) {
 	return branches, nil
 }
 
+// ListSimilarRemoteBranches returns remote branches named branchName, followed
+// by ones whose name ends with it or that it ends with, across a slash
+// boundary (e.g. users/me/feature for feature)
+func ListSimilarRemoteBranches(path, branchName string) ([]RemoteBranch, error) {
+	all, err := ListRemoteBranches(path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var exact, similar []RemoteBranch
+	for _, rb := range all {
+		switch {
+		case rb.Branch == branchName:
+			exact = append(exact, rb)
+		case strings.HasSuffix(rb.Branch, "/"+branchName), strings.HasSuffix(branchName, "/"+rb.Branch):
+			similar = append(similar, rb)
+		}
+	}
+	return append(exact, similar...), nil
+}
+
 // SetUpstream sets the upstream branch for the current branch
 func SetUpstream(path, remote, branch string) error {
+	if err := checkUnlocked(path, configLocks); err != nil {
+		return err
+	}
 	upstream := remote + "/" + branch
 	_, err := runGit(path, "branch", "--set-upstream-to="+upstream)
 	return err
 }
 
+// PushDestination returns the remote and branch that a plain `git push` of
+// the current branch would update, as resolved by @{push}
+func PushDestination(path string) (remote, branch string, err error) {
+	output, err := runGit(path, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{push}")
+	if err != nil {
+		return "", "", err
+	}
+	dest := strings.TrimSpace(output)
+
+	remotes, err := ListRemotes(path)
+	if err != nil {
+		return "", "", err
+	}
+	// Remote names may contain slashes, so prefer the longest match
+	for _, r := range remotes {
+		if strings.HasPrefix(dest, r.Name+"/") && len(r.Name) > len(remote) {
+			remote = r.Name
+		}
+	}
+	if remote == "" {
+		return "", "", fmt.Errorf("cannot resolve push destination %s", dest)
+	}
+	return remote, dest[len(remote)+1:], nil
+}
+
+// PushTo pushes the current branch to the given branch on remote
+func PushTo(path, remote, branch string) error {
+	if err := checkUnlocked(path, refLocks); err != nil {
+		return err
+	}
+	_, err := runGit(path, "push", remote, "HEAD:refs/heads/"+branch)
+	return classifyPushError(path, err)
+}
+
 // PushWithUpstream pushes the current branch and sets upstream tracking
 func PushWithUpstream(path, remote, branch string) error {
+	if err := checkUnlocked(path, upstreamLocks); err != nil {
+		return err
+	}
 	_, err := runGit(path, "push", "-u", remote, branch)
+	return classifyPushError(path, err)
+}
+
+// ValidateTagName reports whether name is a valid tag name that isn't taken yet
+func ValidateTagName(path, name string) error {
+	if _, err := runGit(path, "check-ref-format", "refs/tags/"+name); err != nil {
+		return fmt.Errorf("invalid tag name %q", name)
+	}
+	if _, err := runGit(path, "rev-parse", "--verify", "--quiet", "refs/tags/"+name); err == nil {
+		return fmt.Errorf("tag %s already exists", name)
+	}
+	return nil
+}
+
+// CreateTag tags HEAD, as an annotated tag when message is given and as a
+// lightweight one otherwise
+func CreateTag(path, name, message string) error {
+	if err := checkUnlocked(path, refLocks); err != nil {
+		return err
+	}
+	if err := ValidateTagName(path, name); err != nil {
+		return err
+	}
+	args := []string{"tag"}
+	if message != "" {
+		args = append(args, "-a", "-m", message)
+	}
+	_, err := runGit(path, append(args, name)...)
+	return err
+}
+
+// PushTag pushes a single tag to remote
+func PushTag(path, remote, name string) error {
+	if err := checkUnlocked(path, refLocks); err != nil {
+		return err
+	}
+	_, err := runGit(path, "push", remote, "refs/tags/"+name)
+	return classifyPushError(path, err)
+}
+
+// AmendCommit amends the last commit with any staged changes, replacing its
+// message. An empty message keeps the existing message (including its body).
+func AmendCommit(path, message string) error {
+	if err := checkUnlocked(path, treeLocks); err != nil {
+		return err
+	}
+	args := []string{"commit", "--amend"}
+	if message == "" {
+		args = append(args, "--no-edit")
+	} else {
+		args = append(args, "-m", message)
+	}
+	_, err := runGit(path, args...)
+	return err
+}
+
+// CommitAll stages all changes, including untracked files, and commits them
+func CommitAll(path, message string) error {
+	if err := checkUnlocked(path, treeLocks); err != nil {
+		return err
+	}
+	if _, err := runGit(path, "add", "-A"); err != nil {
+		return err
+	}
+	_, err := runGit(path, "commit", "-m", message)
+	return err
+}
+
+// RecentBranches returns up to n local branches most recently checked out,
+// newest first, as recorded in the HEAD reflog
+func RecentBranches(path string, n int) ([]string, error) {
+	output, err := runGit(path, "reflog", "--format=%gs")
+	if err != nil {
+		return nil, err
+	}
+	heads, err := runGit(path, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[string]bool)
+	for _, b := range strings.Fields(heads) {
+		local[b] = true
+	}
+
+	var branches []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		// e.g. "checkout: moving from main to feature"
+		rest, ok := strings.CutPrefix(line, "checkout: moving from ")
+		if !ok {
+			continue
+		}
+		i := strings.LastIndex(rest, " to ")
+		if i < 0 {
+			continue
+		}
+		// Both ends of a checkout are recent; skip commits and deleted branches
+		for _, b := range []string{rest[i+4:], rest[:i]} {
+			if local[b] && !seen[b] {
+				seen[b] = true
+				branches = append(branches, b)
+			}
+		}
+		if len(branches) >= n {
+			return branches[:n], nil
+		}
+	}
+	return branches, nil
+}
+
+// Checkout switches the working tree to branch
+func Checkout(path, branch string) error {
+	if err := checkUnlocked(path, treeLocks); err != nil {
+		return err
+	}
+	_, err := runGit(path, "checkout", branch)
 	return err
 }
 
 // AddRemote adds a new remote to the repository
 func AddRemote(path, name, url string) error {
+	if err := checkUnlocked(path, configLocks); err != nil {
+		return err
+	}
 	_, err := runGit(path, "remote", "add", name, url)
 	return err
 }
 
+// ChangedFile is a file with uncommitted changes in the working tree
+type ChangedFile struct {
+	Path   string
+	Status string // two-letter porcelain status code, e.g. " M" or "??"
+}
+
+// ChangedFiles returns files with uncommitted changes, as reported by git status
+func ChangedFiles(path string) ([]ChangedFile, error) {
+	output, err := runGit(path, "status", "--porcelain", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []ChangedFile
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		files = append(files, ChangedFile{Path: entry[3:], Status: entry[:2]})
+		// Renames and copies are followed by the original path
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
+		}
+	}
+
+	return files, nil
+}
+
+// FileDiff returns the uncommitted changes to file, staged or not. Untracked
+// files are diffed against nothing, so all of their content shows as added.
+func FileDiff(path, file string) (string, error) {
+	output, err := runGit(path, "diff", "HEAD", "--", file)
+	if err != nil || output != "" {
+		return output, err
+	}
+
+	// --no-index exits with 1 when the files differ
+	cmd := exec.Command(gitBinary(), "diff", "--no-index", "--", os.DevNull, file)
+	cmd.Dir = path
+	cmd.Env = gitEnv()
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		err = nil
+	}
+	return string(out), err
+}
+
+// Commit is a short summary of a single commit
+type Commit struct {
+	Hash    string
+	Author  string
+	Age     string
+	Subject string
+}
+
+// commitFormat is the git log format parsed by parseCommits
+const commitFormat = "--format=%h%x1f%an%x1f%cr%x1f%s"
+
+// parseCommits parses git log output produced with commitFormat
+func parseCommits(output string) []Commit {
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "\x1f", 4)
+		if len(parts) == 4 {
+			commits = append(commits, Commit{Hash: parts[0], Author: parts[1], Age: parts[2], Subject: parts[3]})
+		}
+	}
+	return commits
+}
+
+// LastCommitFor returns the last commit that touched the given file
+// Returns nil if the file has no history (e.g. untracked)
+func LastCommitFor(path, file string) (*Commit, error) {
+	output, err := runGit(path, "log", "-1", commitFormat, "--", file)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := parseCommits(output)
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	return &commits[0], nil
+}
+
+// RecentCommits returns up to n most recent commits on HEAD
+func RecentCommits(path string, n int) ([]Commit, error) {
+	output, err := runGit(path, "log", "-n", strconv.Itoa(n), commitFormat)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(output), nil
+}
+
+// IncomingCommits returns commits on the upstream that aren't on HEAD yet,
+// newest first
+func IncomingCommits(path string) ([]Commit, error) {
+	output, err := runGit(path, "log", commitFormat, "HEAD..@{upstream}")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(output), nil
+}
+
+// OutgoingCommits returns commits on HEAD that aren't on the upstream yet,
+// newest first
+func OutgoingCommits(path string) ([]Commit, error) {
+	output, err := runGit(path, "log", commitFormat, "@{upstream}..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(output), nil
+}
+
+// CommitCountSince returns the number of commits on HEAD committed after
+// since, which is any date git understands (e.g. midnight, 1 week ago)
+func CommitCountSince(path, since string) (int, error) {
+	output, err := runGit(path, "rev-list", "--count", "--since="+since, "HEAD")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
+// CommitActivity returns the number of commits on HEAD for each of the last
+// days calendar days, oldest first
+func CommitActivity(path string, days int) ([]int, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	since := today.AddDate(0, 0, -(days - 1))
+
+	output, err := runGit(path, "log", "--format=%ct", "--since="+since.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, days)
+	for _, line := range strings.Fields(output) {
+		ts, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			continue
+		}
+		t := time.Unix(ts, 0)
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+		// Round to absorb DST shifts in day length
+		idx := int(day.Sub(since).Hours()/24 + 0.5)
+		if idx >= 0 && idx < days {
+			counts[idx]++
+		}
+	}
+
+	return counts, nil
+}
+
+// binaryPath is the git executable used for all commands, unset for git on
+// PATH. A config reload may change it while commands run, hence atomic.
+var binaryPath atomic.Pointer[string]
+
+// SetBinary makes all commands use the git executable at path
+func SetBinary(path string) {
+	binaryPath.Store(&path)
+}
+
+// gitBinary returns the git executable to run
+func gitBinary() string {
+	if path := binaryPath.Load(); path != nil {
+		return *path
+	}
+	return "git"
+}
+
+// CheckBinary reports an error if the git executable can't be found
+func CheckBinary() error {
+	binary := gitBinary()
+	if _, err := exec.LookPath(binary); err != nil {
+		if binary == "git" {
+			return fmt.Errorf("git not found on PATH; install git or set git_path in the config")
+		}
+		return fmt.Errorf("git not found at %s or not executable (check git_path or GITPULSE_GIT)", binary)
+	}
+	if _, err := runGit("", "--version"); err != nil {
+		return fmt.Errorf("%s does not work as git: %v", binary, err)
+	}
+	return nil
+}
+
+// Version returns the version reported by git, e.g. "2.43.0"
+func Version() (string, error) {
+	output, err := runGit("", "--version")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(output), "git version "), nil
+}
+
+// gitEnv is the environment git runs in, plus extra variables. Messages are
+// kept in English whatever the user's locale, since errors are classified by
+// their text.
+func gitEnv(extra ...string) []string {
+	// GIT_OPTIONAL_LOCKS=0 keeps read-only commands like status from taking
+	// index.lock to refresh the index, which would get in the way of whatever
+	// the user runs meanwhile. Commands that write still lock what they need.
+	env := []string{"LC_ALL=C", "LANG=C", "GIT_OPTIONAL_LOCKS=0"}
+	return append(os.Environ(), append(env, extra...)...)
+}
+
 func runGit(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
+	cmd := exec.Command(gitBinary(), args...)
 	cmd.Dir = dir
+	cmd.Env = gitEnv()
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseRemotes(t *testing.T) {
+	output := "upstream\thttps://example.com/up.git (fetch)\n" +
+		"upstream\thttps://example.com/up.git (push)\n" +
+		"origin\tgit@example.com:me/repo.git (fetch)\n" +
+		"origin\tgit@example.com:me/push repo.git (push)\n" +
+		"backup\t/mnt/backup/repo.git (push)\n"
+
+	got := parseRemotes(output)
+	want := []Remote{
+		{Name: "origin", URL: "git@example.com:me/repo.git", PushURL: "git@example.com:me/push repo.git"},
+		{Name: "backup", URL: "/mnt/backup/repo.git", PushURL: "/mnt/backup/repo.git"},
+		{Name: "upstream", URL: "https://example.com/up.git", PushURL: "https://example.com/up.git"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseRemotes =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCountSubmodules(t *testing.T) {
+	porcelain := "1 .M N... 100644 100644 100644 1111111 1111111 main.go\n" +
+		"1 .M SC.. 160000 160000 160000 2222222 2222222 vendor/lib\n" +
+		"1 .M S.M. 160000 160000 160000 3333333 3333333 vendor/other\n" +
+		"u UU S... 160000 160000 160000 160000 4444444 5555555 6666666 vendor/conflict\n" +
+		"? notes.txt\n"
+	if got := countSubmodules(porcelain); got != 3 {
+		t.Errorf("countSubmodules = %d, want 3", got)
+	}
+	if got := countSubmodules(""); got != 0 {
+		t.Errorf("countSubmodules of clean repo = %d, want 0", got)
+	}
+}
+
+func TestSubmoduleState(t *testing.T) {
+	tests := []struct {
+		sub  Submodule
+		want string
+	}{
+		{Submodule{Initialized: true}, "clean"},
+		{Submodule{}, "not initialized"},
+		{Submodule{Initialized: true, Moved: true, Dirty: true}, "moved, modified"},
+		{Submodule{Initialized: true, Behind: 3}, "3 behind"},
+	}
+	for _, tt := range tests {
+		if got := tt.sub.State(); got != tt.want {
+			t.Errorf("%+v.State() = %q, want %q", tt.sub, got, tt.want)
+		}
+		if got := tt.sub.Clean(); got != (tt.want == "clean") {
+			t.Errorf("%+v.Clean() = %v", tt.sub, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// HookError is a push that was stopped by a hook rather than by the remote
+// refusing the commits, e.g. a failing local pre-push hook or a server-side
+// pre-receive hook declining the update
+type HookError struct {
+	Hook string // e.g. pre-push, pre-receive
+	Err  error
+}
+
+func (e *HookError) Error() string {
+	return e.Hook + " hook failed: " + e.Err.Error()
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// declinedHook matches server-side rejections like "(pre-receive hook declined)"
+var declinedHook = regexp.MustCompile(`\(([\w-]+) hook declined\)`)
+
+// classifyPushError wraps push errors caused by a hook in a HookError. Git
+// doesn't name a failing pre-push hook, so a failed push with no rejected refs
+// in a repo that has one is blamed on it.
+func classifyPushError(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if m := declinedHook.FindStringSubmatch(msg); m != nil {
+		return &HookError{Hook: m[1], Err: err}
+	}
+	if strings.Contains(msg, "failed to push some refs") && !strings.Contains(msg, "rejected]") {
+		if hooks, _ := Hooks(path); slices.Contains(hooks, "pre-push") {
+			return &HookError{Hook: "pre-push", Err: err}
+		}
+	}
+	return err
+}
+
+// HooksPath returns the configured core.hooksPath, or "" when hooks live in
+// the default .git/hooks
+func HooksPath(path string) string {
+	output, err := runGit(path, "config", "--get", "core.hooksPath")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// Hooks returns the names of the active hooks of a repo, honouring
+// core.hooksPath. Sample hooks and files that aren't executable are skipped,
+// as git would not run them.
+func Hooks(path string) ([]string, error) {
+	output, err := runGit(path, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return nil, err
+	}
+	dir := strings.TrimSpace(output)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(path, dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var hooks []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".sample") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.Mode()&0o111 == 0 {
+			continue
+		}
+		hooks = append(hooks, e.Name())
+	}
+	slices.Sort(hooks)
+	return hooks, nil
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// worktreeLocks are lock files git holds in a worktree's own git directory
+// while writing to it, and commonLocks ones in the directory shared by all
+// worktrees
+var (
+	worktreeLocks = []string{"index.lock", "HEAD.lock"}
+	commonLocks   = []string{"shallow.lock", "config.lock", "packed-refs.lock"}
+)
+
+// The locks each kind of operation needs free. Only ones touching the working
+// tree care about the index; fetching or pushing just updates refs.
+var (
+	allLocks    = slices.Concat(worktreeLocks, commonLocks)
+	treeLocks   = []string{"index.lock", "HEAD.lock", "packed-refs.lock"}
+	refLocks    = []string{"packed-refs.lock"}
+	fetchLocks  = []string{"packed-refs.lock", "shallow.lock"}
+	configLocks = []string{"config.lock"}
+	// Pushing with -u also records the upstream in the config
+	upstreamLocks = []string{"packed-refs.lock", "config.lock"}
+)
+
+// LockedError is returned by operations refused because another git process
+// holds a lock on the repo
+type LockedError struct {
+	File string // e.g. index.lock
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("repo is locked by another git process (%s)", e.File)
+}
+
+// HeldLock returns the name of a lock file held on the repo at path, e.g.
+// index.lock, or "" when there is none. A git process that crashed can leave
+// one behind; then it has to be removed by hand.
+func HeldLock(path string) string {
+	return heldLock(path, allLocks)
+}
+
+// heldLock is HeldLock restricted to the lock files in names
+func heldLock(path string, names []string) string {
+	output, err := runGit(path, "rev-parse", "--absolute-git-dir", "--git-common-dir")
+	if err != nil {
+		return ""
+	}
+	gitDir, commonDir, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(path, commonDir)
+	}
+	for _, name := range worktreeLocks {
+		if !slices.Contains(names, name) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(gitDir, name)); err == nil {
+			return name
+		}
+	}
+	for _, name := range commonLocks {
+		if !slices.Contains(names, name) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(commonDir, name)); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// checkUnlocked refuses to modify a repo another git process holds one of
+// the given locks on, rather than failing halfway with git's own error
+func checkUnlocked(path string, names []string) error {
+	if name := heldLock(path, names); name != "" {
+		return &LockedError{File: name}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckUnlockedOnlyNeededLocks(t *testing.T) {
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Skipf("git init: %v: %s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "index.lock"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, locks := range [][]string{fetchLocks, refLocks, configLocks} {
+		if err := checkUnlocked(dir, locks); err != nil {
+			t.Errorf("checkUnlocked(%v) = %v, want nil with only index.lock held", locks, err)
+		}
+	}
+	var locked *LockedError
+	if err := checkUnlocked(dir, treeLocks); !errors.As(err, &locked) || locked.File != "index.lock" {
+		t.Errorf("checkUnlocked(treeLocks) = %v, want index.lock held", err)
+	}
+	if got := HeldLock(dir); got != "index.lock" {
+		t.Errorf("HeldLock = %q, want index.lock", got)
+	}
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Reachability is whether a repo's remote answered a connectivity check
+type Reachability int
+
+const (
+	ReachUnknown Reachability = iota // Not checked
+	Reachable
+	Unreachable
+)
+
+// reachTimeout bounds how long a connectivity check may take, so offline
+// remotes don't hold up a refresh
+const reachTimeout = 5 * time.Second
+
+// reachTTL is how long a connectivity check result is reused, so periodic
+// refreshes don't go over the network every time
+const reachTTL = 5 * time.Minute
+
+type reachResult struct {
+	reach Reachability
+	at    time.Time
+}
+
+var (
+	reachMu    sync.Mutex
+	reachCache = make(map[string]reachResult) // By repo path and remote
+)
+
+// CheckRemote reports whether remote answers ls-remote within reachTimeout.
+// Results are reused for reachTTL.
+func CheckRemote(path, remote string) Reachability {
+	key := path + "\x00" + remote
+	reachMu.Lock()
+	cached, ok := reachCache[key]
+	reachMu.Unlock()
+	if ok && time.Since(cached.at) < reachTTL {
+		return cached.reach
+	}
+
+	reach := Unreachable
+	_, err := lsRemote(path, "--exit-code", remote, "HEAD")
+	// Exit code 2 means the remote answered but has no HEAD (e.g. it's empty)
+	var remoteErr *lsRemoteError
+	if err == nil || errors.As(err, &remoteErr) && remoteErr.code == 2 {
+		reach = Reachable
+	}
+
+	reachMu.Lock()
+	reachCache[key] = reachResult{reach: reach, at: time.Now()}
+	reachMu.Unlock()
+	return reach
+}
+
+// lsRemoteError is an ls-remote that failed with git's message and exit code
+type lsRemoteError struct {
+	msg  string
+	code int
+}
+
+func (e *lsRemoteError) Error() string {
+	return e.msg
+}
+
+// lsRemote runs git ls-remote in dir, giving up after reachTimeout.
+// Credential prompts are disabled, since nobody would see them.
+func lsRemote(dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), reachTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, gitBinary(), append([]string{"ls-remote"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = gitEnv("GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("timed out after %s", reachTimeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", &lsRemoteError{msg: msg, code: exitErr.ExitCode()}
+	}
+	return string(output), err
+}
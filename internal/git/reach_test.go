@@ -0,0 +1,37 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckRemoteReusesResults(t *testing.T) {
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	repo := filepath.Join(dir, "repo")
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", remote},
+		{"init", "-q", repo},
+		{"-C", repo, "remote", "add", "origin", remote},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	// An empty remote has no HEAD, but it answered
+	if got := CheckRemote(repo, "origin"); got != Reachable {
+		t.Fatalf("CheckRemote = %v, want Reachable", got)
+	}
+	if err := os.RemoveAll(remote); err != nil {
+		t.Fatal(err)
+	}
+	if got := CheckRemote(repo, "origin"); got != Reachable {
+		t.Errorf("CheckRemote within reachTTL = %v, want the cached Reachable", got)
+	}
+	if got := CheckRemote(repo, "nowhere"); got != Unreachable {
+		t.Errorf("CheckRemote of a missing remote = %v, want Unreachable", got)
+	}
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// IsShallow reports whether the repo at path is a shallow clone
+func IsShallow(path string) bool {
+	output, err := runGit(path, "rev-parse", "--is-shallow-repository")
+	return err == nil && strings.TrimSpace(output) == "true"
+}
+
+// Unshallow fetches the full history of a shallow clone. Fetching it can take
+// a while, so each progress line git reports is passed to progress.
+// Credential prompts are disabled, since nobody would see them.
+func Unshallow(path string, progress func(line string)) error {
+	if err := checkUnlocked(path, fetchLocks); err != nil {
+		return err
+	}
+	cmd := exec.Command(gitBinary(), "fetch", "--unshallow", "--progress")
+	cmd.Dir = path
+	cmd.Env = gitEnv("GIT_TERMINAL_PROMPT=0")
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Progress lines are redrawn in place with \r
+	var last string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			last = line
+			progress(line)
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if last == "" {
+			last = err.Error()
+		}
+		return fmt.Errorf("%s", last)
+	}
+	return nil
+}
+
+// scanProgressLines is a bufio.SplitFunc that ends lines at \r as well as \n
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
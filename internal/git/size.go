@@ -0,0 +1,53 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RepoSize returns the disk space taken by the repo's object store, loose
+// and packed, in a human-readable form like 124 MB
+func RepoSize(path string) (string, error) {
+	output, err := runGit(path, "count-objects", "-v")
+	if err != nil {
+		return "", err
+	}
+
+	// Sizes are reported in KiB
+	var kib int64
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok || key != "size" && key != "size-pack" {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("unexpected count-objects output: %q", line)
+		}
+		kib += n
+	}
+	return formatSize(kib * 1024), nil
+}
+
+// formatSize renders a byte count with one decimal below 10 of a unit, e.g.
+// 512 B, 3.4 MB, 124 MB
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	size := float64(bytes)
+	suffix := ""
+	for _, s := range []string{"KB", "MB", "GB", "TB"} {
+		size /= unit
+		suffix = s
+		if size < unit {
+			break
+		}
+	}
+	if size < 10 {
+		return fmt.Sprintf("%.1f %s", size, suffix)
+	}
+	return fmt.Sprintf("%.0f %s", size, suffix)
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Submodule is the state of a submodule, nested ones included
+type Submodule struct {
+	Path        string // Relative to the top-level repo
+	Initialized bool
+	Moved       bool // Checked out commit differs from the one recorded
+	Conflict    bool // Merge conflicts in the recorded commit
+	Dirty       bool // Uncommitted changes in its working tree
+	Behind      int  // Commits on its upstream it doesn't have, as last fetched
+}
+
+// Clean reports whether the submodule needs no attention
+func (s Submodule) Clean() bool {
+	return s.Initialized && !s.Moved && !s.Conflict && !s.Dirty && s.Behind == 0
+}
+
+// State describes what needs attention in a submodule, e.g. "modified"
+func (s Submodule) State() string {
+	var states []string
+	switch {
+	case !s.Initialized:
+		return "not initialized"
+	case s.Conflict:
+		states = append(states, "conflict")
+	case s.Moved:
+		states = append(states, "moved")
+	}
+	if s.Dirty {
+		states = append(states, "modified")
+	}
+	if s.Behind > 0 {
+		states = append(states, fmt.Sprintf("%d behind", s.Behind))
+	}
+	if len(states) == 0 {
+		return "clean"
+	}
+	return strings.Join(states, ", ")
+}
+
+// countSubmodules counts the changed submodules in `git status
+// --porcelain=v2` output, so refreshes need no extra git calls. Submodules
+// that aren't initialized don't show up there; SubmoduleStatus lists them.
+func countSubmodules(porcelain string) int {
+	n := 0
+	for _, line := range strings.Split(porcelain, "\n") {
+		// Changed and unmerged entries: <kind> <XY> <sub> ..., where <sub>
+		// is S<c><m><u> for submodules and N... otherwise
+		fields := strings.Fields(line)
+		if len(fields) > 2 && (fields[0] == "1" || fields[0] == "2" || fields[0] == "u") && strings.HasPrefix(fields[2], "S") {
+			n++
+		}
+	}
+	return n
+}
+
+// SubmoduleStatus returns the submodules of the repo at path, recursively.
+// Repos without a .gitmodules file have none, and cost no git calls.
+func SubmoduleStatus(path string) ([]Submodule, error) {
+	if _, err := os.Stat(filepath.Join(path, ".gitmodules")); err != nil {
+		return nil, nil
+	}
+	output, err := runGit(path, "submodule", "status", "--recursive")
+	if err != nil {
+		return nil, err
+	}
+
+	var subs []Submodule
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		// <state char><hash> <path> (<describe>)
+		fields := strings.Fields(line[min(1, len(line)):])
+		if len(fields) < 2 {
+			continue
+		}
+		sub := Submodule{
+			Path:        fields[1],
+			Initialized: line[0] != '-',
+			Moved:       line[0] == '+',
+			Conflict:    line[0] == 'U',
+		}
+		if sub.Initialized {
+			// Nested submodules are listed on their own
+			porcelain, _ := runGit(filepath.Join(path, sub.Path), "status", "--porcelain", "--ignore-submodules")
+			sub.Dirty = strings.TrimSpace(porcelain) != ""
+			sub.Behind = submoduleBehind(filepath.Join(path, sub.Path))
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// submoduleBehind counts the commits the submodule at path lacks from its
+// upstream. Submodules are usually on a detached HEAD, so without an upstream
+// it's compared to the default branch of origin instead. Nothing is fetched.
+func submoduleBehind(path string) int {
+	for _, upstream := range []string{"@{upstream}", "refs/remotes/origin/HEAD"} {
+		output, err := runGit(path, "rev-list", "--count", "HEAD.."+upstream)
+		if err != nil {
+			continue
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(output))
+		return n
+	}
+	return 0
+}
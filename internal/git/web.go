@@ -0,0 +1,115 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// splitRemoteURL splits a remote URL (https, ssh or scp-like) into its host
+// and repository path
+func splitRemoteURL(remoteURL string) (host, repoPath string, err error) {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid remote URL %s", remoteURL)
+		}
+		return u.Hostname(), u.Path, nil
+	} else if at, colon := strings.Index(remoteURL, "@"), strings.Index(remoteURL, ":"); at >= 0 && colon > at {
+		// scp-like: git@host:org/repo.git
+		return remoteURL[at+1 : colon], remoteURL[colon+1:], nil
+	}
+	return "", "", fmt.Errorf("remote %s is not hosted on the web", remoteURL)
+}
+
+// WebURL converts a remote URL (https, ssh or scp-like) into the https URL
+// of the repository's web page
+func WebURL(remoteURL string) (string, error) {
+	host, repoPath, err := splitRemoteURL(remoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || repoPath == "" {
+		return "", fmt.Errorf("invalid remote URL %s", remoteURL)
+	}
+	return "https://" + host + "/" + repoPath, nil
+}
+
+// URLHost returns the host of a remote URL, or "local" for remotes on the
+// filesystem
+func URLHost(remoteURL string) (string, error) {
+	host, _, err := splitRemoteURL(remoteURL)
+	if err != nil && strings.Contains(remoteURL, "://") {
+		return "", err
+	}
+	if host == "" {
+		return "local", nil
+	}
+	return host, nil
+}
+
+// RemoteHost returns the host of the repo's origin remote, or of its first
+// remote when there is no origin
+func RemoteHost(path string) (string, error) {
+	remotes, err := ListRemotes(path)
+	if err != nil {
+		return "", err
+	}
+	if len(remotes) == 0 {
+		return "", fmt.Errorf("no remotes")
+	}
+	remote := remotes[0]
+	for _, r := range remotes {
+		if r.Name == "origin" {
+			remote = r
+		}
+	}
+	return URLHost(remote.URL)
+}
+
+// CommitURL returns the web page of the HEAD commit on the upstream remote
+// (origin when there is no upstream). Only GitHub and GitLab are supported,
+// and the commit must already be pushed.
+func CommitURL(path string) (string, error) {
+	remote := "origin"
+	if branch, err := runGit(path, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		remote = branchRemote(path, strings.TrimSpace(branch))
+	}
+
+	remoteURL, err := runGit(path, "remote", "get-url", remote)
+	if err != nil {
+		return "", fmt.Errorf("no remote %s", remote)
+	}
+	web, err := WebURL(strings.TrimSpace(remoteURL))
+	if err != nil {
+		return "", err
+	}
+
+	var commitPath string
+	host := strings.TrimPrefix(web, "https://")
+	host = host[:strings.Index(host, "/")]
+	switch {
+	case host == "github.com":
+		commitPath = "/commit/"
+	case strings.Contains(host, "gitlab"):
+		commitPath = "/-/commit/"
+	default:
+		return "", fmt.Errorf("unsupported host %s", host)
+	}
+
+	contains, err := runGit(path, "branch", "-r", "--contains", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(contains) == "" {
+		return "", fmt.Errorf("HEAD is not pushed")
+	}
+
+	head, err := runGit(path, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return web + commitPath + strings.TrimSpace(head), nil
+}
@@ -0,0 +1,44 @@
+package git
+
+import "strings"
+
+// Worktree is a working tree attached to a repository
+type Worktree struct {
+	Path     string
+	Head     string // Full hash of the checked out commit
+	Branch   string // Short branch name; empty when detached
+	Bare     bool
+	Prunable bool // The directory is gone, git would prune it
+}
+
+// ListWorktrees returns the worktrees of the repo at path, the main one first
+func ListWorktrees(path string) ([]Worktree, error) {
+	output, err := runGit(path, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(output), "\n\n") {
+		var wt Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.Path = value
+			case "HEAD":
+				wt.Head = value
+			case "branch":
+				wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				wt.Bare = true
+			case "prunable":
+				wt.Prunable = true
+			}
+		}
+		if wt.Path != "" {
+			worktrees = append(worktrees, wt)
+		}
+	}
+	return worktrees, nil
+}
@@ -0,0 +1,49 @@
+package oplog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger appends timestamped operation results to a file
+// A nil Logger is valid and discards everything
+type Logger struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// Open opens path for appending, creating it if needed
+func Open(path string) (*Logger, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return &Logger{f: f}, nil
+}
+
+// Log records the outcome of an operation on a repo
+func (l *Logger) Log(repo, op string, err error) {
+	if l == nil {
+		return
+	}
+
+	result := "ok"
+	if err != nil {
+		// Keep one entry per line even for multi-line git errors
+		result = "failed: " + strings.Join(strings.Fields(err.Error()), " ")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.f, "%s %s %s %s\n", time.Now().Format(time.RFC3339), repo, op, result)
+}
+
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.f.Close()
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// recentBranchLimit is the number of branches offered by the quick switcher
+const recentBranchLimit = 10
+
+// branchesLoadedMsg carries recently checked out branches of a repo
+type branchesLoadedMsg struct {
+	repoRef
+	branches []string
+	err      error
+}
+
+type checkoutCompleteMsg struct {
+	repoRef
+	branch string
+	err    error
+}
+
+// showRecentBranches opens the quick switcher for recently used branches
+func (m *Model) showRecentBranches(index int) tea.Cmd {
+	ref := m.ref(index)
+	status := m.statuses[index]
+	if status.RemoteOnly || status.Error != nil {
+		return nil
+	}
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		branches, err := git.RecentBranches(path, recentBranchLimit)
+		return branchesLoadedMsg{repoRef: ref, branches: branches, err: err}
+	}
+}
+
+func (m *Model) checkout(index int, branch string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		err := git.Checkout(path, branch)
+		return checkoutCompleteMsg{repoRef: ref, branch: branch, err: err}
+	}
+}
+
+// handleBranchesKey handles keys in the branch switcher
+func (m Model) handleBranchesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "b":
+		m.modalType = ModalNone
+		m.modalBranches = nil
+	case "up", "k":
+		if m.modalCursor > 0 {
+			m.modalCursor--
+		}
+	case "down", "j":
+		if m.modalCursor < len(m.modalBranches)-1 {
+			m.modalCursor++
+		}
+	case "enter", " ":
+		branch := m.modalBranches[m.modalCursor]
+		m.modalType = ModalNone
+		m.modalBranches = nil
+		return m, m.checkout(m.modalRepoIndex, branch)
+	}
+	return m, nil
+}
+
+// renderBranches returns the body of the branch switcher
+func (m Model) renderBranches() string {
+	t := m.theme
+	current := m.statuses[m.modalRepoIndex].Branch
+	var lines []string
+	for i, branch := range m.modalBranches {
+		cursor := cursorGlyph(m.glyphs, i == m.modalCursor)
+		style := lipgloss.NewStyle().Foreground(t.RepoName)
+		if i == m.modalCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+		}
+		line := cursor + style.Render(branch)
+		if branch == current {
+			line += lipgloss.NewStyle().Foreground(t.Dim).Render(" (current)")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// branchesTitle returns the title of the branch switcher
+func (m Model) branchesTitle() string {
+	return fmt.Sprintf("Switch branch in %s", m.statuses[m.modalRepoIndex].Name)
+}
@@ -0,0 +1,74 @@
+package ui
+
+import "strings"
+
+// minColumnWidth is the narrowest a column may get before fewer are used
+const minColumnWidth = 60
+
+// columnGap is the space between columns
+const columnGap = 3
+
+// columnCount returns how many columns the list is laid out in: up to the
+// configured count, as many as fit the terminal, and no more than there are
+// repos to show
+func (m *Model) columnCount() int {
+	width := m.width
+	if width < 60 {
+		width = 80
+	}
+	innerWidth := width - 4
+
+	cols := m.cfg.ColumnCount()
+	for cols > 1 && (innerWidth-(cols-1)*columnGap)/cols < minColumnWidth {
+		cols--
+	}
+	if n := len(m.displayOrder()); cols > n {
+		cols = max(n, 1)
+	}
+	return cols
+}
+
+// gridRows returns the number of rows the list takes up. Repos fill the grid
+// column by column, so the display order reads top to bottom like ls.
+func (m *Model) gridRows() int {
+	n := len(m.displayOrder())
+	cols := m.columnCount()
+	return (n + cols - 1) / cols
+}
+
+// cursorRow returns the grid row the cursor is on
+func (m *Model) cursorRow() int {
+	if rows := m.gridRows(); rows > 0 {
+		return m.cursor % rows
+	}
+	return 0
+}
+
+// moveColumn moves the cursor to the same row in the neighboring column, if
+// that cell holds a repo
+func (m *Model) moveColumn(delta int) {
+	target := m.cursor + delta*m.gridRows()
+	if target >= 0 && target < len(m.displayOrder()) {
+		m.cursor = target
+	}
+}
+
+// gridLines arranges one line per repo into rows of cols cells, each padded
+// to cellWidth
+func gridLines(lines []string, cols, cellWidth int) []string {
+	if cols <= 1 {
+		return lines
+	}
+	rows := (len(lines) + cols - 1) / cols
+	grid := make([]string, rows)
+	for r := range grid {
+		var cells []string
+		for c := 0; c < cols; c++ {
+			if i := c*rows + r; i < len(lines) {
+				cells = append(cells, pad(lines[i], cellWidth))
+			}
+		}
+		grid[r] = strings.Join(cells, strings.Repeat(" ", columnGap))
+	}
+	return grid
+}
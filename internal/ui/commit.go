@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+type commitCompleteMsg struct {
+	repoRef
+	err error
+}
+
+// commitBatch tracks a commit across several repos to report the outcome once
+type commitBatch struct {
+	total     int
+	pending   int
+	committed int
+}
+
+// showCommitModal prompts for a message to commit all changes in the given
+// repos. Repos that aren't dirty are skipped.
+func (m *Model) showCommitModal(indices []int) tea.Cmd {
+	var targets []int
+	for _, i := range indices {
+		s := m.statuses[i]
+		if s.Dirty && s.Error == nil && !s.RemoteOnly {
+			targets = append(targets, i)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	m.modalType = ModalCommit
+	m.modalRepoIndex = targets[0]
+	m.commitTargets = targets
+	m.textInput.Placeholder = "commit message"
+	m.textInput.Reset()
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+// dirtyRepos returns the indices of the dirty repos a bulk commit covers
+func (m Model) dirtyRepos() []int {
+	var indices []int
+	for i, s := range m.statuses {
+		if s.Dirty && m.inBatch(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func (m *Model) commitAll(index int, message string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		err := git.CommitAll(path, message)
+		return commitCompleteMsg{repoRef: ref, err: err}
+	}
+}
+
+// handleCommitKey handles keys in the commit prompt
+func (m Model) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.modalType = ModalNone
+		m.commitTargets = nil
+		m.textInput.Blur()
+		return m, nil
+	case "enter":
+		message := strings.TrimSpace(m.textInput.Value())
+		if message == "" {
+			return m, nil
+		}
+		m.modalType = ModalNone
+		m.textInput.Blur()
+		m.commitBatch = commitBatch{total: len(m.commitTargets), pending: len(m.commitTargets)}
+		cmds := make([]tea.Cmd, 0, len(m.commitTargets))
+		for _, i := range m.commitTargets {
+			cmds = append(cmds, m.commitAll(i, message))
+		}
+		m.commitTargets = nil
+		return m, tea.Batch(cmds...)
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// commitDone records a finished commit, summarizing batches once all are done
+func (m *Model) commitDone(msg commitCompleteMsg) {
+	m.logger.Log(m.repos[msg.index].Name, "commit", msg.err)
+	if msg.err != nil {
+		setMessage(m.statuses[msg.index], fmt.Sprintf("commit failed: %v", msg.err))
+	} else {
+		setMessage(m.statuses[msg.index], "committed")
+		m.commitBatch.committed++
+	}
+	m.commitBatch.pending--
+	if m.commitBatch.pending == 0 && m.commitBatch.total > 1 {
+		m.notice = fmt.Sprintf("committed %d of %d repos", m.commitBatch.committed, m.commitBatch.total)
+	}
+}
+
+// renderCommit returns the title and body of the commit prompt
+func (m Model) renderCommit() (string, string) {
+	t := m.theme
+	var title, note string
+	if len(m.commitTargets) == 1 {
+		title = fmt.Sprintf("Commit all changes in %s", m.statuses[m.commitTargets[0]].Name)
+		note = "Stages everything, including untracked files."
+	} else {
+		names := make([]string, 0, len(m.commitTargets))
+		for _, i := range m.commitTargets {
+			names = append(names, m.statuses[i].Name)
+		}
+		title = fmt.Sprintf("Commit all changes in %d repos", len(m.commitTargets))
+		note = "Stages everything, including untracked files, in: " + strings.Join(names, ", ")
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(t.Dim).Render(note),
+		"",
+		m.textInput.View(),
+	}
+	return title, strings.Join(lines, "\n")
+}
@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// detailFileLimit caps how many changed files get a last-commit lookup
+const detailFileLimit = 8
+
+// activityDays is the number of days covered by the activity sparkline
+const activityDays = 7
+
+// sparkBlocks are the block characters used to draw sparklines, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// detailFile is a changed file along with the last commit that touched it
+type detailFile struct {
+	file git.ChangedFile
+	last *git.Commit
+}
+
+// repoDetail holds data loaded on demand for the detail view
+type repoDetail struct {
+	files      []detailFile
+	totalFiles int
+	activity   []int // commits per day, oldest first; nil when disabled
+	hooks      []string
+	hooksPath  string // core.hooksPath, if set
+	submodules []git.Submodule
+	size       string // Object store size; empty when it couldn't be read
+	err        error
+}
+
+type detailLoadedMsg struct {
+	repoRef
+	detail repoDetail
+}
+
+func (m *Model) showDetail(index int) tea.Cmd {
+	m.modalType = ModalDetail
+	m.modalRepoIndex = index
+	m.detail = nil
+	m.detailCursor = 0
+	if m.repos[index].Remote {
+		m.detail = &repoDetail{}
+		return nil
+	}
+	return m.loadDetail(index)
+}
+
+func (m *Model) loadDetail(index int) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	showActivity := m.cfg.ActivityEnabled()
+	return func() tea.Msg {
+		var d repoDetail
+		if showActivity {
+			d.activity, _ = git.CommitActivity(path, activityDays)
+		}
+		d.hooks, _ = git.Hooks(path)
+		d.hooksPath = git.HooksPath(path)
+		d.submodules, _ = git.SubmoduleStatus(path)
+		d.size, _ = git.RepoSize(path)
+		files, err := git.ChangedFiles(path)
+		if err != nil {
+			d.err = err
+			return detailLoadedMsg{repoRef: ref, detail: d}
+		}
+		d.totalFiles = len(files)
+		if len(files) > detailFileLimit {
+			files = files[:detailFileLimit]
+		}
+		for _, f := range files {
+			last, _ := git.LastCommitFor(path, f.Path)
+			d.files = append(d.files, detailFile{file: f, last: last})
+		}
+		return detailLoadedMsg{repoRef: ref, detail: d}
+	}
+}
+
+// renderDetail returns the title and body of the detail view
+func (m Model) renderDetail() (string, string) {
+	t := m.theme
+	status := m.statuses[m.modalRepoIndex]
+	dim := lipgloss.NewStyle().Foreground(t.Dim)
+	label := lipgloss.NewStyle().Foreground(t.HelpText)
+	value := lipgloss.NewStyle().Foreground(t.RepoName)
+
+	var lines []string
+	field := func(name, val string) {
+		lines = append(lines, label.Render(fmt.Sprintf("%-9s", name))+" "+value.Render(val))
+	}
+
+	if status.RemoteOnly {
+		field("Remote", status.Path)
+		if status.Error != nil {
+			field("Error", status.Error.Error())
+		} else {
+			field("HEAD", status.RemoteHead)
+		}
+		if status.RemoteChanged {
+			field("Seen", m.state.RemoteHeads[status.Path]+" (f to mark as seen)")
+		}
+		return status.Name, strings.Join(lines, "\n")
+	}
+
+	field("Path", status.Path)
+	if status.Error != nil {
+		field("Error", status.Error.Error())
+		return status.Name, strings.Join(lines, "\n")
+	}
+	if status.Detached {
+		field("HEAD", "detached at "+status.HeadLabel())
+	} else {
+		field("Branch", status.Branch)
+	}
+	if status.HasUpstream {
+		g := m.glyphs
+		field("Upstream", fmt.Sprintf("%s (%s%d %s%d)", status.Upstream, g.Ahead, status.Ahead, g.Behind, status.Behind))
+		if status.PushRef != "" {
+			field("Push to", fmt.Sprintf("%s (%s%d)", status.PushRef, g.PushAhead, status.PushAhead))
+		}
+	} else {
+		field("Upstream", "none")
+	}
+	if at, ok := m.state.Reviewed[status.Path]; ok {
+		field("Reviewed", at.Format("2006-01-02 15:04")+dim.Render("  (R to mark again)"))
+	}
+	if status.Custom != "" {
+		field("Custom", status.Custom)
+	}
+	if status.Locked != "" {
+		field("Locked", status.Locked+" held by another git process")
+	}
+	if status.CommitSubject != "" {
+		field("Commit", fmt.Sprintf("%s (%s)", status.CommitSubject, status.CommitAge))
+	}
+	// Newest first, so the latest result lines up with the list
+	for i := len(status.History) - 1; i >= 0; i-- {
+		name := ""
+		if i == len(status.History)-1 {
+			name = "Ops"
+		}
+		field(name, status.History[i])
+	}
+
+	if m.detail != nil && m.detail.activity != nil {
+		total := 0
+		for _, n := range m.detail.activity {
+			total += n
+		}
+		field("Activity", lipgloss.NewStyle().Foreground(t.Synced).Render(sparkline(m.detail.activity))+
+			dim.Render(fmt.Sprintf("  %d commits in %d days", total, len(m.detail.activity))))
+	}
+	if m.detail != nil && m.detail.size != "" {
+		field("Size", m.detail.size+dim.Render("  (git objects)"))
+	}
+	if m.detail != nil && len(m.detail.hooks) > 0 {
+		hooks := strings.Join(m.detail.hooks, ", ")
+		if m.detail.hooksPath != "" {
+			hooks += dim.Render("  (core.hooksPath " + m.detail.hooksPath + ")")
+		}
+		field("Hooks", hooks)
+	}
+
+	if m.detail != nil && len(m.detail.submodules) > 0 {
+		clean := 0
+		var subs []string
+		for _, sub := range m.detail.submodules {
+			if sub.Clean() {
+				clean++
+				continue
+			}
+			subs = append(subs, value.Render(sub.Path)+" "+
+				lipgloss.NewStyle().Foreground(t.Ahead).Render(sub.State()))
+		}
+		if clean > 0 {
+			subs = append(subs, dim.Render(fmt.Sprintf("%d clean", clean)))
+		}
+		for i, sub := range subs {
+			name := ""
+			if i == 0 {
+				name = "Submodule"
+			}
+			field(name, sub)
+		}
+	}
+
+	lines = append(lines, "")
+	switch {
+	case m.detail == nil:
+		lines = append(lines, dim.Render(m.spinner.View()+" loading…"))
+	case m.detail.err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Error).Render(m.detail.err.Error()))
+	case m.detail.totalFiles == 0:
+		lines = append(lines, dim.Render("Working tree clean"))
+	default:
+		lines = append(lines, label.Render("Changed files (last touched by)"))
+		for i, f := range m.detail.files {
+			touch := "no history"
+			if f.last != nil {
+				touch = fmt.Sprintf("%s, %s (%s)", f.last.Author, f.last.Age, f.last.Hash)
+			}
+			name := value.Render(f.file.Path)
+			if i == m.detailCursor {
+				name = lipgloss.NewStyle().Bold(true).Foreground(t.Selected).Render(f.file.Path)
+			}
+			lines = append(lines, fmt.Sprintf("%s%s %s  %s",
+				cursorGlyph(m.glyphs, i == m.detailCursor),
+				lipgloss.NewStyle().Foreground(t.Ahead).Render(f.file.Status),
+				name,
+				dim.Render(touch)))
+		}
+		if more := m.detail.totalFiles - len(m.detail.files); more > 0 {
+			lines = append(lines, dim.Render(fmt.Sprintf("… and %d more", more)))
+		}
+	}
+
+	return status.Name, strings.Join(lines, "\n")
+}
+
+// sparkline renders counts as a row of block characters scaled to the maximum
+func sparkline(counts []int) string {
+	max := 0
+	for _, n := range counts {
+		if n > max {
+			max = n
+		}
+	}
+
+	var b strings.Builder
+	for _, n := range counts {
+		level := 0
+		if max > 0 && n > 0 {
+			// Any activity shows above the baseline
+			level = 1 + n*(len(sparkBlocks)-2)/max
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderErrors returns the body of the errors panel: every repo with an
+// error, followed by its full (wrapped) message
+func (m Model) renderErrors(wrapWidth int) []string {
+	t := m.theme
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(t.RepoName)
+	errStyle := lipgloss.NewStyle().Foreground(t.Error).Width(wrapWidth)
+
+	var lines []string
+	for i, s := range m.statuses {
+		if s.Error == nil {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, nameStyle.Render(s.Name)+" "+
+			lipgloss.NewStyle().Foreground(t.Dim).Render(m.repos[i].Path))
+		lines = append(lines, strings.Split(errStyle.Render(s.Error.Error()), "\n")...)
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Synced).Render("No errors"))
+	}
+	return lines
+}
+
+// errorCount returns the number of repos currently in an error state
+func (m Model) errorCount() int {
+	n := 0
+	for _, s := range m.statuses {
+		if s.Error != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// errorsTitle returns the title of the errors panel
+func (m Model) errorsTitle() string {
+	return fmt.Sprintf("Errors (%d)", m.errorCount())
+}
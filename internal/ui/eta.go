@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// startOp records when a fetch or sync of a repo began
+func (m *Model) startOp(index int) {
+	if m.opStarted == nil {
+		m.opStarted = make(map[string]time.Time)
+	}
+	m.opStarted[m.repos[index].Path] = time.Now()
+}
+
+// finishOp records how long a repo's fetch or sync took, when it was part of
+// a bulk operation
+func (m *Model) finishOp(index int) {
+	path := m.repos[index].Path
+	started, ok := m.opStarted[path]
+	if !ok {
+		return
+	}
+	delete(m.opStarted, path)
+	if m.fetchingAll {
+		m.bulkDurations = append(m.bulkDurations, time.Since(started))
+	}
+}
+
+// bulkETA estimates how long the running bulk operation has left. Repos are
+// fetched concurrently, so this is the longest remaining time of the repos
+// still in flight, assuming each takes as long as the finished ones did on
+// average. Unknown until some repo has finished.
+func (m Model) bulkETA() (time.Duration, bool) {
+	if !m.fetchingAll || len(m.bulkDurations) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, d := range m.bulkDurations {
+		total += d
+	}
+	avg := total / time.Duration(len(m.bulkDurations))
+
+	var eta time.Duration
+	found := false
+	for i, s := range m.statuses {
+		started, ok := m.opStarted[m.repos[i].Path]
+		if !ok || !s.Fetching {
+			continue
+		}
+		found = true
+		eta = max(eta, avg-time.Since(started))
+	}
+	return eta, found
+}
+
+// formatETA renders a remaining time estimate, e.g. ~12s left
+func formatETA(d time.Duration) string {
+	if d < time.Second {
+		return "~1s left"
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("~%ds left", int(d.Round(time.Second)/time.Second))
+	}
+	return fmt.Sprintf("~%dm left", int(d.Round(time.Minute)/time.Minute))
+}
@@ -0,0 +1,56 @@
+package ui
+
+// Filter restricts the repo list to repos matching a status predicate
+type Filter int
+
+const (
+	FilterNone Filter = iota
+	FilterBehind
+	FilterDirty
+	FilterAhead
+	FilterErrors
+	FilterOffDefault
+)
+
+// filterKeys maps quick filter keys to the filter they toggle
+var filterKeys = map[string]Filter{
+	"1": FilterBehind,
+	"2": FilterDirty,
+	"3": FilterAhead,
+	"4": FilterErrors,
+	"5": FilterOffDefault,
+}
+
+// Match reports whether a repo status passes the filter
+func (f Filter) Match(s *repoStatus) bool {
+	switch f {
+	case FilterBehind:
+		return s.NeedsPull() || s.RemoteChanged
+	case FilterDirty:
+		return s.Dirty && s.Error == nil
+	case FilterAhead:
+		return s.NeedsPush()
+	case FilterErrors:
+		return s.Error != nil
+	case FilterOffDefault:
+		return s.DefaultBranch != "" && s.Branch != s.DefaultBranch && s.Error == nil
+	}
+	return true
+}
+
+// Label returns a short human-readable name for the filter
+func (f Filter) Label() string {
+	switch f {
+	case FilterBehind:
+		return "behind"
+	case FilterDirty:
+		return "dirty"
+	case FilterAhead:
+		return "ahead"
+	case FilterErrors:
+		return "errors"
+	case FilterOffDefault:
+		return "off default branch"
+	}
+	return ""
+}
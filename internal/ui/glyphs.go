@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/config"
+)
+
+var DefaultGlyphs = config.Glyphs{
+	Synced:     "✓",
+	Ahead:      "↑",
+	PushAhead:  "⇡",
+	Behind:     "↓",
+	NoUpstream: "○",
+	Error:      "✗",
+	Remote:     "◌",
+	News:       "●",
+	Reachable:  "●",
+	Cursor:     "▸",
+	Mark:       "✓",
+}
+
+// configGlyphs returns the default glyphs with the overrides from the
+// config's [glyphs] section applied on top
+func configGlyphs(cfg *config.Config) config.Glyphs {
+	o, d := cfg.Glyphs, DefaultGlyphs
+	return config.Glyphs{
+		Synced:     cmp.Or(o.Synced, d.Synced),
+		Ahead:      cmp.Or(o.Ahead, d.Ahead),
+		PushAhead:  cmp.Or(o.PushAhead, d.PushAhead),
+		Behind:     cmp.Or(o.Behind, d.Behind),
+		NoUpstream: cmp.Or(o.NoUpstream, d.NoUpstream),
+		Error:      cmp.Or(o.Error, d.Error),
+		Remote:     cmp.Or(o.Remote, d.Remote),
+		News:       cmp.Or(o.News, d.News),
+		Reachable:  cmp.Or(o.Reachable, d.Reachable),
+		Cursor:     cmp.Or(o.Cursor, d.Cursor),
+		Mark:       cmp.Or(o.Mark, d.Mark),
+	}
+}
+
+// cursorGlyph returns the cursor glyph followed by a space when selected, or
+// blanks of the same width otherwise, so lists stay aligned
+func cursorGlyph(g config.Glyphs, selected bool) string {
+	if selected {
+		return g.Cursor + " "
+	}
+	return strings.Repeat(" ", lipgloss.Width(g.Cursor)+1)
+}
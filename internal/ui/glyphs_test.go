@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+)
+
+func TestConfigGlyphsOverridesDefaults(t *testing.T) {
+	g := configGlyphs(&config.Config{Glyphs: config.Glyphs{PushAhead: "^", News: "*"}})
+	if g.PushAhead != "^" || g.News != "*" {
+		t.Errorf("overrides not applied: %+v", g)
+	}
+	if g.Remote != DefaultGlyphs.Remote || g.Reachable != DefaultGlyphs.Reachable {
+		t.Errorf("defaults not kept: %+v", g)
+	}
+}
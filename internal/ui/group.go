@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"cmp"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+)
+
+// groupMatchers tell whether a repo belongs to a grouping category. A repo
+// is grouped under the first category in the configured order it matches.
+var groupMatchers = map[string]func(s *repoStatus) bool{
+	"error":       func(s *repoStatus) bool { return s.Error != nil },
+	"behind":      func(s *repoStatus) bool { return s.NeedsPull() || s.RemoteChanged },
+	"diverged":    func(s *repoStatus) bool { return s.IsDiverged() && s.Error == nil },
+	"ahead":       func(s *repoStatus) bool { return s.NeedsPush() },
+	"dirty":       func(s *repoStatus) bool { return s.Dirty && s.Error == nil },
+	"synced":      func(s *repoStatus) bool { return s.IsSynced() },
+	"no-upstream": func(s *repoStatus) bool { return !s.HasUpstream || s.RemoteOnly },
+}
+
+// groupOrder returns the configured category order followed by the default
+// categories it leaves out
+func groupOrder(cfg *config.Config) []string {
+	order := append([]string(nil), cfg.GroupOrder...)
+	listed := make(map[string]bool)
+	for _, name := range order {
+		listed[name] = true
+	}
+	for _, name := range config.DefaultGroupOrder {
+		if !listed[name] {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// statusPriority returns a sort priority for a repo status
+// Lower values appear first when grouped
+func (m *Model) statusPriority(s *repoStatus) int {
+	for i, name := range m.groupOrder {
+		if match, ok := groupMatchers[name]; ok && match(s) {
+			return i
+		}
+	}
+	return len(m.groupOrder)
+}
+
+// noRemoteSection is the host section of repos without a remote
+const noRemoteSection = "no remote"
+
+// hostSection returns the host section a repo is grouped under
+func hostSection(s *repoStatus) string {
+	return cmp.Or(s.RemoteHost, noRemoteSection)
+}
+
+// compareHosts orders host sections by name, with repos without a remote last
+func compareHosts(a, b string) int {
+	if (a == noRemoteSection) != (b == noRemoteSection) {
+		if a == noRemoteSection {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Compare(a, b)
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+func TestStatusPriorityDirtyBeforeBehind(t *testing.T) {
+	m := Model{groupOrder: groupOrder(&config.Config{})}
+	dirtyBehind := &repoStatus{RepoStatus: git.RepoStatus{HasUpstream: true, Behind: 2, Dirty: true}}
+	behind := &repoStatus{RepoStatus: git.RepoStatus{HasUpstream: true, Behind: 2}}
+	ahead := &repoStatus{RepoStatus: git.RepoStatus{HasUpstream: true, Ahead: 1}}
+
+	if got, want := m.groupOrder[m.statusPriority(dirtyBehind)], "dirty"; got != want {
+		t.Errorf("dirty and behind repo grouped as %q, want %q", got, want)
+	}
+	if m.statusPriority(dirtyBehind) >= m.statusPriority(behind) {
+		t.Errorf("dirty repo sorts after behind one")
+	}
+	if m.statusPriority(dirtyBehind) >= m.statusPriority(ahead) {
+		t.Errorf("dirty repo sorts after ahead one")
+	}
+}
+
+func TestGroupOrderKeepsConfiguredFirst(t *testing.T) {
+	got := groupOrder(&config.Config{GroupOrder: []string{"synced", "behind"}})
+	want := []string{"synced", "behind", "error", "dirty", "ahead", "no-upstream"}
+	if !slices.Equal(got, want) {
+		t.Errorf("groupOrder = %v, want %v", got, want)
+	}
+}
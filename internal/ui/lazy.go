@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listHeight returns how many repo rows fit on screen, or 0 when the window
+// size is not known yet
+func (m Model) listHeight() int {
+	if m.height == 0 {
+		return 0
+	}
+	// Margin, border, padding, title and summary
+	chrome := 11
+	if m.hiddenCount() > 0 {
+		// The note on repos beyond max_visible
+		chrome++
+	}
+	if m.showHelp {
+		// The help line wraps on narrow terminals
+		var parts []string
+		for _, item := range helpItems {
+			parts = append(parts, item.key+" "+item.desc)
+		}
+		chrome += lipgloss.Height(lipgloss.NewStyle().Width(m.width - 6).Render(strings.Join(parts, "  ")))
+	}
+	if h := m.height - chrome; h > 1 {
+		return h
+	}
+	return 1
+}
+
+// scrollToCursor adjusts the list offset so the cursor row stays visible
+func (m *Model) scrollToCursor() {
+	height := m.listHeight()
+	if height == 0 {
+		return
+	}
+	row := m.cursorRow()
+	if row < m.listOffset {
+		m.listOffset = row
+	}
+	if row >= m.listOffset+height {
+		m.listOffset = row - height + 1
+	}
+	if max := m.gridRows() - height; m.listOffset > max {
+		m.listOffset = max
+	}
+	if m.listOffset < 0 {
+		m.listOffset = 0
+	}
+}
+
+// isLoaded reports whether a repo's status has been requested. Outside lazy
+// mode every repo counts as loaded, except manual_refresh ones.
+func (m Model) isLoaded(index int) bool {
+	return m.loaded[m.repos[index].Path] || (!m.cfg.LazyLoading() && !m.repos[index].ManualRefresh)
+}
+
+// autoRefresh reports whether a repo is refreshed without being acted on
+func (m Model) autoRefresh(index int) bool {
+	return m.isLoaded(index) && !m.repos[index].ManualRefresh
+}
+
+// loadVisible requests the status of on-screen repos that haven't been loaded
+func (m *Model) loadVisible() tea.Cmd {
+	if !m.cfg.LazyLoading() {
+		return nil
+	}
+	order := m.displayOrder()
+	rows := m.gridRows()
+	end := rows
+	if height := m.listHeight(); height > 0 && m.listOffset+height < end {
+		end = m.listOffset + height
+	} else if height == 0 {
+		// Window size unknown yet: nothing is visible
+		return nil
+	}
+	if m.listOffset >= end {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for pos, i := range order {
+		// Repos fill the grid column by column
+		if row := pos % rows; row < m.listOffset || row >= end {
+			continue
+		}
+		if !m.loaded[m.repos[i].Path] && !m.repos[i].ManualRefresh {
+			cmds = append(cmds, m.refreshStatus(i, m.repos[i]))
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,26 @@
+package ui
+
+// visibleLimit returns how many rows max_visible allows, or 0 when the list
+// isn't limited or was expanded with +
+func (m Model) visibleLimit() int {
+	if m.showAll {
+		return 0
+	}
+	return m.cfg.MaxVisible
+}
+
+// hiddenCount returns how many repos matching the filter are left out of the
+// list by max_visible
+func (m Model) hiddenCount() int {
+	limit := m.visibleLimit()
+	if limit <= 0 {
+		return 0
+	}
+	n := 0
+	for _, s := range m.statuses {
+		if m.filter.Match(s) {
+			n++
+		}
+	}
+	return max(0, n-limit)
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+func TestInBatchSkipsReposBeyondMaxVisible(t *testing.T) {
+	m := &Model{
+		cfg: &config.Config{MaxVisible: 1},
+		statuses: []*repoStatus{
+			{RepoStatus: git.RepoStatus{Name: "a"}},
+			{RepoStatus: git.RepoStatus{Name: "b"}},
+		},
+	}
+	if !m.inBatch(0) || m.inBatch(1) {
+		t.Errorf("inBatch = %v, %v; want only the listed repo", m.inBatch(0), m.inBatch(1))
+	}
+	m.showAll = true
+	if !m.inBatch(1) {
+		t.Errorf("repo listed with + left out of bulk actions")
+	}
+}
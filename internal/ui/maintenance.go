@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+type maintenanceCompleteMsg struct {
+	repoRef
+	err error
+}
+
+// maintainable reports whether git maintenance can run on a repo now
+func maintainable(s *repoStatus) bool {
+	return !s.RemoteOnly && s.Error == nil && !s.Maintaining && !s.Fetching && !s.Rebasing
+}
+
+// runMaintenance runs git maintenance on a repo in the background
+func (m *Model) runMaintenance(index int) tea.Cmd {
+	status := m.statuses[index]
+	if !maintainable(status) {
+		return nil
+	}
+	status.Maintaining = true
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	m.remember(index, "maintenance", (*Model).runMaintenance)
+	return func() tea.Msg {
+		err := git.Maintenance(path)
+		return maintenanceCompleteMsg{repoRef: ref, err: err}
+	}
+}
+
+// confirmMaintenanceAll lists the repos maintenance would run on, running it
+// on all of them on confirmation
+func (m *Model) confirmMaintenanceAll(index int) tea.Cmd {
+	var targets []int
+	for i, s := range m.statuses {
+		if maintainable(s) && m.inBatch(i) {
+			targets = append(targets, i)
+		}
+	}
+	if len(targets) == 0 {
+		m.notice = "no repos to maintain"
+		return nil
+	}
+	names := make([]string, len(targets))
+	for i, idx := range targets {
+		names[i] = m.repos[idx].Name
+	}
+	cmd := m.showText(index, fmt.Sprintf("Run maintenance on %d repos", len(targets)), func() ([]string, error) {
+		return append([]string{"git maintenance run --auto can take a while per repo:", ""}, names...), nil
+	})
+	m.textConfirmLabel = "run maintenance"
+	m.textConfirm = func(m *Model) tea.Cmd {
+		cmds := make([]tea.Cmd, 0, len(targets))
+		for _, idx := range targets {
+			cmds = append(cmds, m.runMaintenance(idx))
+		}
+		return tea.Batch(cmds...)
+	}
+	return cmd
+}
+
+// maintenanceComplete records the outcome of git maintenance on a repo
+func (m *Model) maintenanceComplete(msg maintenanceCompleteMsg) tea.Cmd {
+	m.logger.Log(m.repos[msg.index].Name, "maintenance", msg.err)
+	m.settle(msg.index, msg.err)
+	status := m.statuses[msg.index]
+	status.Maintaining = false
+	if msg.err != nil {
+		setMessage(status, fmt.Sprintf("maintenance failed: %v", msg.err))
+	} else {
+		setMessage(status, "maintenance done")
+	}
+	return m.errorBell(msg.err)
+}
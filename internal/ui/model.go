@@ -2,59 +2,103 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/d12frosted/gitpulse/internal/config"
 	"github.com/d12frosted/gitpulse/internal/git"
+	"github.com/d12frosted/gitpulse/internal/oplog"
+	"github.com/mattn/go-runewidth"
 )
 
 const refreshInterval = 30 * time.Second
 
 // Messages
 type statusUpdatedMsg struct {
-	index  int
+	repoRef
 	status *git.RepoStatus
 }
 
 type fetchCompleteMsg struct {
-	index int
-	err   error
+	repoRef
+	err error
 }
 
 type pullCompleteMsg struct {
-	index int
-	err   error
+	repoRef
+	before string // HEAD before the pull
+	after  string // HEAD after it; the same as before when nothing came in
+	err    error
 }
 
 type pushCompleteMsg struct {
-	index int
-	err   error
+	repoRef
+	err error
 }
 
 type fetchAllCompleteMsg struct{}
 
 type refreshTickMsg time.Time
 
+// RefreshMsg asks the model to refresh all repo statuses, e.g. when sent from
+// outside the program
+type RefreshMsg struct{}
+
 type remotesLoadedMsg struct {
-	index    int
+	repoRef
 	remotes  []git.Remote
 	branches []git.RemoteBranch
 }
 
+type pushTargetsMsg struct {
+	repoRef
+	options []UpstreamOption
+}
+
 type upstreamSetMsg struct {
-	index int
-	err   error
+	repoRef
+	err error
 }
 
 type remoteAddedMsg struct {
-	index int
-	err   error
+	repoRef
+	err error
+}
+
+type shellExitedMsg struct {
+	repoRef
+	err error
+}
+
+type browserOpenedMsg struct {
+	repoRef
+	err error
+}
+
+type configEditedMsg struct {
+	err error
+}
+
+type configReloadedMsg struct {
+	cfg   *config.Config
+	repos []config.RepoConfig
+}
+
+type amendCompleteMsg struct {
+	repoRef
+	err error
 }
 
 // ModalType represents the type of modal being shown
@@ -64,6 +108,15 @@ const (
 	ModalNone ModalType = iota
 	ModalSetUpstream
 	ModalAddRemote
+	ModalDetail
+	ModalErrors
+	ModalText
+	ModalAmend
+	ModalPush
+	ModalBranches
+	ModalCommit
+	ModalTag
+	ModalTheme
 )
 
 // UpstreamOption represents an option in the set upstream modal
@@ -75,24 +128,78 @@ type UpstreamOption struct {
 
 // Model
 type Model struct {
+	cfg         *config.Config
+	state       *config.State
 	repos       []config.RepoConfig
-	statuses    []*git.RepoStatus
+	statuses    []*repoStatus
 	cursor      int
+	listOffset  int
 	spinner     spinner.Model
 	width       int
 	height      int
 	fetchingAll bool
 	grouped     bool
+	groupByHost bool // Group by remote host rather than by status
+	groupOrder  []string
+	abbreviate  bool
+	hideCommits bool // Commit subjects are hidden, leaving room for branches
+	showAll     bool // Repos beyond max_visible are listed too
+	showHelp    bool
+	filter      Filter
 	quitting    bool
+	inline      bool // Rendered in the main screen, so the last frame stays on quit
+	adhoc       bool // Repos didn't come from the config, so it's never written
 	theme       Theme
+	glyphs      config.Glyphs
+	logger      *oplog.Logger
+	watcher     *repoWatcher
+	loaded      map[string]bool // Repos whose status was requested, for lazy loading
+	refreshing  map[string]int  // Pending status refreshes, by repo path
+	userRefresh map[string]bool // Repos refreshed with r, dimmed until all their refreshes are in
+	notice      string          // Outcome of the last bulk action, shown in the summary
+	jumping     bool            // Waiting for a repo alias after '
+	marked      map[int]bool    // Repos picked with x; bulk actions only touch these when any are
+
+	// Timing of fetches and syncs, for the bulk operation ETA
+	opStarted     map[string]time.Time // By repo path
+	bulkDurations []time.Duration
+
+	// Last operation dispatched on each repo, by path, until it succeeds, for
+	// retrying with .
+	lastOps map[string]retryOp
+
+	// Behind counts before fetches, and the commits they brought in, by path
+	behindBefore map[string]int
+	newCommits   map[string]newCommits
 
 	// Modal state
 	modalType       ModalType
 	modalRepoIndex  int
 	modalOptions    []UpstreamOption
+	modalBranches   []string
+	commitTargets   []int
+	commitBatch     commitBatch
 	modalCursor     int
 	modalAfterSetup bool // true if we should fetch/sync after setting upstream
 	textInput       textinput.Model
+	detail          *repoDetail
+	detailCursor    int // Selected changed file in the detail view
+	modalScroll     int
+	textTitle       string
+	textLines       []string
+	textErr         error
+	tagName         string // Name entered in the tag prompt, before its message
+	tagErr          error
+	themeNames      []string
+
+	// Optional action offered by the text modal on enter
+	textConfirm      func(m *Model) tea.Cmd
+	textConfirmLabel string
+
+	title string // Terminal title last set, with set_terminal_title
+
+	// Modal the text modal returns to when closed, e.g. the detail view
+	textParent ModalType
 }
 
 // formatMessage adds a timestamp prefix to operation messages
@@ -100,11 +207,25 @@ func formatMessage(msg string) string {
 	return fmt.Sprintf("[%s] %s", time.Now().Format("02/01/06 15:04:05"), msg)
 }
 
-func NewModel(repos []config.RepoConfig, themeName string) Model {
-	theme := GetTheme(themeName)
+// historySize is how many operation results are kept per repo
+const historySize = 10
+
+// setMessage records an operation result as the repo's last message and
+// appends it to its history, dropping the oldest entries beyond historySize
+func setMessage(s *repoStatus, msg string) {
+	s.LastMessage = formatMessage(msg)
+	s.History = append(s.History, s.LastMessage)
+	if n := len(s.History); n > historySize {
+		s.History = append([]string(nil), s.History[n-historySize:]...)
+	}
+}
+
+func NewModel(repos []config.RepoConfig, cfg *config.Config) Model {
+	repos = expandWorktrees(repos)
+	theme := configTheme(cfg)
 
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = GetSpinner(cfg.Spinner)
 	s.Style = lipgloss.NewStyle().Foreground(theme.Spinner)
 
 	ti := textinput.New()
@@ -112,53 +233,167 @@ func NewModel(repos []config.RepoConfig, themeName string) Model {
 	ti.CharLimit = 256
 	ti.Width = 40
 
-	statuses := make([]*git.RepoStatus, len(repos))
+	// State is best-effort: without it remote-only repos just start unseen
+	state, _ := config.LoadState()
+	state.MigratePaths(repos)
+
+	statuses := make([]*repoStatus, len(repos))
 	for i, repo := range repos {
-		statuses[i] = &git.RepoStatus{
-			Path: repo.Path,
-			Name: repo.Name,
-		}
+		statuses[i] = placeholderStatus(repo)
+	}
+
+	// Watching is best-effort: without it repos still refresh on the timer
+	var watcher *repoWatcher
+	if cfg.WatchEnabled() {
+		watcher, _ = newRepoWatcher(repos)
 	}
 
 	return Model{
-		repos:     repos,
-		statuses:  statuses,
-		spinner:   s,
-		grouped:   true,
-		theme:     theme,
-		textInput: ti,
+		cfg:         cfg,
+		state:       state,
+		repos:       repos,
+		statuses:    statuses,
+		spinner:     s,
+		grouped:     cfg.GroupedByDefault(),
+		groupByHost: cfg.GroupByHost(),
+		groupOrder:  groupOrder(cfg),
+		showHelp:    cfg.HelpVisible(),
+		watcher:     watcher,
+		loaded:      make(map[string]bool),
+		refreshing:  make(map[string]int),
+		theme:       theme,
+		glyphs:      configGlyphs(cfg),
+		textInput:   ti,
 	}
 }
 
-// statusPriority returns a sort priority for a repo status
-// Lower values appear first when grouped
-func statusPriority(s *git.RepoStatus) int {
-	if s.Error != nil {
-		return 0 // Errors first
+// loadRepos lists the repos of a freshly loaded config in the background,
+// since expanding worktrees runs git for each repo that asks for it
+func loadRepos(cfg *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		return configReloadedMsg{cfg: cfg, repos: expandWorktrees(cfg.RepoConfigs())}
 	}
-	if s.NeedsPull() {
-		return 1 // Needs pull (behind)
+}
+
+// reloadConfig applies a freshly loaded config, keeping the status of repos
+// that are still listed and loading added ones
+func (m *Model) reloadConfig(cfg *config.Config, repos []config.RepoConfig) tea.Cmd {
+	known := make(map[string]*repoStatus, len(m.statuses))
+	for _, s := range m.statuses {
+		known[s.Path] = s
 	}
-	if s.NeedsPush() {
-		return 2 // Needs push (ahead)
+	statuses := make([]*repoStatus, len(repos))
+	for i, repo := range repos {
+		if s, ok := known[repo.Path]; ok {
+			s.Name = repo.Name
+			statuses[i] = s
+		} else {
+			statuses[i] = placeholderStatus(repo)
+		}
+	}
+
+	git.SetBinary(cfg.GitBinary())
+	m.cfg = cfg
+	m.repos = repos
+	m.statuses = statuses
+	m.theme = configTheme(cfg)
+	m.glyphs = configGlyphs(cfg)
+	m.groupOrder = groupOrder(cfg)
+	m.spinner.Spinner = GetSpinner(cfg.Spinner)
+	m.spinner.Style = lipgloss.NewStyle().Foreground(m.theme.Spinner)
+	m.marked = nil
+	m.clampCursor()
+
+	// Watch the repos of the new config instead
+	if m.watcher != nil {
+		m.watcher.close()
+		m.watcher = nil
 	}
-	if s.IsSynced() {
-		return 3 // Synced
+	cmds := []tea.Cmd{m.refreshAll()}
+	if cfg.WatchEnabled() {
+		m.watcher, _ = newRepoWatcher(repos)
 	}
-	return 4 // No upstream
+	if m.watcher != nil {
+		cmds = append(cmds, m.watcher.wait())
+	}
+	return tea.Batch(cmds...)
 }
 
-// displayOrder returns indices in display order (sorted if grouped)
+// placeholderStatus is the status shown for a repo before it is loaded
+func placeholderStatus(repo config.RepoConfig) *repoStatus {
+	return &repoStatus{RepoStatus: git.RepoStatus{
+		Path:       repo.Path,
+		Name:       repo.Name,
+		RemoteOnly: repo.Remote,
+	}}
+}
+
+// WithLogger returns the model with operation results recorded to l
+func (m Model) WithLogger(l *oplog.Logger) Model {
+	m.logger = l
+	return m
+}
+
+// WithoutAltScreen returns the model for running outside the alternate
+// screen, leaving its final state in the scrollback on quit
+func (m Model) WithoutAltScreen() Model {
+	m.inline = true
+	return m
+}
+
+// WithAdHocRepos returns the model for repos given on the command line rather
+// than listed in the config. The config then only provides settings, and is
+// neither edited nor saved.
+func (m Model) WithAdHocRepos() Model {
+	m.adhoc = true
+	return m
+}
+
+// Spinners maps config names to spinner styles
+var Spinners = map[string]spinner.Spinner{
+	"dot":       spinner.Dot,
+	"line":      spinner.Line,
+	"minidot":   spinner.MiniDot,
+	"jump":      spinner.Jump,
+	"pulse":     spinner.Pulse,
+	"points":    spinner.Points,
+	"globe":     spinner.Globe,
+	"moon":      spinner.Moon,
+	"monkey":    spinner.Monkey,
+	"meter":     spinner.Meter,
+	"hamburger": spinner.Hamburger,
+	"ellipsis":  spinner.Ellipsis,
+}
+
+// GetSpinner returns the named spinner style, falling back to dot
+func GetSpinner(name string) spinner.Spinner {
+	if s, ok := Spinners[name]; ok {
+		return s
+	}
+	return spinner.Dot
+}
+
+// displayOrder returns indices in display order (filtered, sorted if grouped,
+// and cut to max_visible)
 func (m *Model) displayOrder() []int {
-	indices := make([]int, len(m.statuses))
-	for i := range indices {
-		indices[i] = i
+	indices := make([]int, 0, len(m.statuses))
+	for i, s := range m.statuses {
+		if m.filter.Match(s) {
+			indices = append(indices, i)
+		}
 	}
 
 	if m.grouped {
 		sort.Slice(indices, func(a, b int) bool {
-			pa := statusPriority(m.statuses[indices[a]])
-			pb := statusPriority(m.statuses[indices[b]])
+			// By host first, then by status within each host
+			if m.groupByHost {
+				ha, hb := hostSection(m.statuses[indices[a]]), hostSection(m.statuses[indices[b]])
+				if c := compareHosts(ha, hb); c != 0 {
+					return c < 0
+				}
+			}
+			pa := m.statusPriority(m.statuses[indices[a]])
+			pb := m.statusPriority(m.statuses[indices[b]])
 			if pa != pb {
 				return pa < pb
 			}
@@ -167,12 +402,55 @@ func (m *Model) displayOrder() []int {
 		})
 	}
 
+	if limit := m.visibleLimit(); limit > 0 && len(indices) > limit {
+		indices = indices[:limit]
+	}
 	return indices
 }
 
 // selectedIndex returns the actual repo index for the current cursor position
-func (m *Model) selectedIndex() int {
-	return m.displayOrder()[m.cursor]
+// The second result is false when no repo is visible (e.g. filtered out)
+func (m *Model) selectedIndex() (int, bool) {
+	order := m.displayOrder()
+	if m.cursor < 0 || m.cursor >= len(order) {
+		return 0, false
+	}
+	return order[m.cursor], true
+}
+
+// jumpTo moves the cursor to the repo with the given alias, clearing a
+// filter that hides it
+func (m *Model) jumpTo(alias string) {
+	for i, repo := range m.repos {
+		if repo.Alias == "" || repo.Alias != alias {
+			continue
+		}
+		if !m.filter.Match(m.statuses[i]) {
+			m.filter = FilterNone
+		}
+		if !slices.Contains(m.displayOrder(), i) {
+			m.showAll = true
+		}
+		for pos, idx := range m.displayOrder() {
+			if idx == i {
+				m.cursor = pos
+			}
+		}
+		return
+	}
+	if alias != "esc" {
+		m.notice = fmt.Sprintf("no repo with alias %s", alias)
+	}
+}
+
+// clampCursor keeps the cursor within the visible list
+func (m *Model) clampCursor() {
+	if n := len(m.displayOrder()); m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
 }
 
 func (m Model) Init() tea.Cmd {
@@ -181,9 +459,17 @@ func (m Model) Init() tea.Cmd {
 		m.scheduleRefresh(),
 	}
 
-	// Refresh all statuses on start
-	for i, repo := range m.repos {
-		cmds = append(cmds, m.refreshStatus(i, repo))
+	// Refresh all statuses on start; lazy mode waits for the window size to
+	// know which repos are visible
+	if !m.cfg.LazyLoading() {
+		for i, repo := range m.repos {
+			if !repo.ManualRefresh {
+				cmds = append(cmds, m.refreshStatus(i, repo))
+			}
+		}
+	}
+	if m.watcher != nil {
+		cmds = append(cmds, m.watcher.wait())
 	}
 
 	return tea.Batch(cmds...)
@@ -195,20 +481,166 @@ func (m Model) scheduleRefresh() tea.Cmd {
 	})
 }
 
+// pullBehind syncs every repo that can fast-forward cleanly. Diverged and
+// dirty repos are skipped and marked so they can be handled manually.
+func (m *Model) pullBehind() tea.Cmd {
+	var cmds []tea.Cmd
+	skipped := 0
+	for i, s := range m.statuses {
+		if !s.NeedsPull() || s.Fetching || !m.inBatch(i) {
+			continue
+		}
+		switch {
+		case s.IsDiverged():
+			setMessage(s, "skipped: diverged from upstream")
+			skipped++
+		case s.Dirty:
+			setMessage(s, "skipped: uncommitted changes")
+			skipped++
+		default:
+			s.Fetching = true
+			s.LastMessage = ""
+			cmds = append(cmds, m.fetchAndPull(i))
+		}
+	}
+	m.notice = fmt.Sprintf("pulling %d repos, skipped %d", len(cmds), skipped)
+	if len(cmds) == 0 {
+		return nil
+	}
+	m.fetchingAll = true
+	return tea.Batch(cmds...)
+}
+
+// inBatch reports whether a bulk action should include a repo: every repo when
+// none are marked, only the marked ones otherwise. Repos hidden by the active
+// filter are left out too, unless bulk_respects_filter is off, and so are
+// repos cut off by max_visible.
+func (m *Model) inBatch(index int) bool {
+	matches := m.filter.Match(m.statuses[index])
+	if m.cfg.BulkRespectsFilter() && !matches {
+		return false
+	}
+	if matches && m.visibleLimit() > 0 && !slices.Contains(m.displayOrder(), index) {
+		return false
+	}
+	return len(m.marked) == 0 || m.marked[index]
+}
+
+// finishBulk ends a fetch/sync all once no repo is fetching anymore, ringing
+// the bell if configured
+func (m *Model) finishBulk() tea.Cmd {
+	if !m.fetchingAll {
+		return nil
+	}
+	for _, s := range m.statuses {
+		if s.Fetching {
+			return nil
+		}
+	}
+	m.fetchingAll = false
+	m.bulkDurations = nil
+	if m.cfg.RingOnComplete() {
+		return ringBell
+	}
+	return nil
+}
+
+// errorBell rings the bell for a failed operation if configured
+func (m *Model) errorBell(err error) tea.Cmd {
+	if err == nil || !m.cfg.RingOnError() {
+		return nil
+	}
+	return ringBell
+}
+
+// ringBell writes the terminal bell character
+func ringBell() tea.Msg {
+	os.Stdout.WriteString("\a")
+	return nil
+}
+
+// refreshAll refreshes the status of every repo, except manual_refresh ones
+func (m *Model) refreshAll() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.repos))
+	for i, repo := range m.repos {
+		if m.autoRefresh(i) {
+			cmds = append(cmds, m.refreshStatus(i, repo))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *Model) refreshStatus(index int, repo config.RepoConfig) tea.Cmd {
+	ref := m.ref(index)
+	m.loaded[repo.Path] = true
+	m.refreshing[repo.Path]++
+	if repo.Remote {
+		seen := m.state.RemoteHeads[repo.Path]
+		return func() tea.Msg {
+			status := git.GetRemoteStatus(repo.Path, repo.Name, seen)
+			status.RemoteHost, _ = git.URLHost(repo.Path)
+			return statusUpdatedMsg{repoRef: ref, status: status}
+		}
+	}
+	diffStat := m.cfg.DiffStatEnabled()
+	checkRemote := m.cfg.RemoteCheckEnabled()
+	since := m.cfg.CommitsSince
+	hosts := m.groupByHost
+	statusCommand := m.cfg.StatusCommandArgs()
 	return func() tea.Msg {
 		status := git.GetStatus(repo.Path, repo.Name)
-		return statusUpdatedMsg{index: index, status: status}
+		if len(statusCommand) > 0 && status.Error == nil {
+			custom, err := runStatusCommand(statusCommand, repo.Path)
+			if err != nil {
+				custom = "?"
+			}
+			status.Custom = custom
+		}
+		if diffStat && status.Dirty && status.Error == nil {
+			status.Insertions, status.Deletions, _ = git.DiffStat(repo.Path)
+		}
+		if checkRemote && status.HasUpstream && status.Error == nil {
+			remote, _, _ := strings.Cut(status.Upstream, "/")
+			status.RemoteReachable = git.CheckRemote(repo.Path, remote)
+		}
+		if since != "" && status.Error == nil {
+			status.RecentCommits, _ = git.CommitCountSince(repo.Path, since)
+		}
+		if hosts && status.Error == nil {
+			status.RemoteHost, _ = git.RemoteHost(repo.Path)
+		}
+		return statusUpdatedMsg{repoRef: ref, status: status}
 	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	tm, cmd := m.update(msg)
+	m = tm.(Model)
+	// Keep the cursor on screen and load repos that scrolled into view
+	m.scrollToCursor()
+	if load := m.loadVisible(); load != nil {
+		cmd = tea.Batch(cmd, load)
+	}
+	if title := m.updateTitle(); title != nil {
+		cmd = tea.Batch(cmd, title)
+	}
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// Handle modal input first
 		if m.modalType != ModalNone {
 			return m.handleModalKey(msg)
 		}
+		m.notice = ""
+
+		if m.jumping {
+			m.jumping = false
+			m.jumpTo(msg.String())
+			return m, nil
+		}
 
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
@@ -218,20 +650,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
+			} else if n := len(m.displayOrder()); n > 0 && m.cfg.WrapsNavigation() {
+				m.cursor = n - 1
 			}
 
 		case "down", "j":
-			if m.cursor < len(m.repos)-1 {
+			if m.cursor < len(m.displayOrder())-1 {
 				m.cursor++
+			} else if m.cfg.WrapsNavigation() {
+				m.cursor = 0
 			}
 
+		case "'":
+			m.jumping = true
+			m.notice = "jump to: press a repo alias"
+
+		case "x":
+			// Mark the repo for bulk actions
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			if m.marked[idx] {
+				delete(m.marked, idx)
+			} else {
+				if m.marked == nil {
+					m.marked = make(map[int]bool)
+				}
+				m.marked[idx] = true
+			}
+
+		case "X":
+			m.marked = nil
+
+		case "left", "h":
+			m.moveColumn(-1)
+
+		case "right", "l":
+			m.moveColumn(1)
+
+		case "shift+up", "K":
+			m.moveSelected(-1)
+
+		case "shift+down", "J":
+			m.moveSelected(1)
+
 		case "f":
 			// Fetch single repo
-			idx := m.selectedIndex()
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
 			status := m.statuses[idx]
 			if status.Fetching {
 				return m, nil
 			}
+			// Remote-only repos have nothing to fetch, mark news as seen instead
+			if status.RemoteOnly {
+				m.markRemoteSeen(idx)
+				return m, m.refreshStatus(idx, m.repos[idx])
+			}
 			// DWIM: If no upstream, show modal to set one
 			if !status.HasUpstream && status.Error == nil {
 				return m, m.showUpstreamModal(idx, false)
@@ -241,31 +719,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.fetchRepo(idx)
 
 		case "F":
-			// Fetch all repos
+			// Fetch all repos, skipping ones fetched within the freshness window
 			if !m.fetchingAll {
 				m.fetchingAll = true
+				window := m.cfg.FreshFetchWindow()
 				cmds := make([]tea.Cmd, 0, len(m.repos))
 				for i := range m.repos {
+					if m.statuses[i].RemoteOnly || !m.inBatch(i) {
+						continue
+					}
+					if last := m.statuses[i].LastFetchTime; window > 0 && !last.IsZero() && time.Since(last) < window {
+						continue
+					}
 					m.statuses[i].Fetching = true
 					cmds = append(cmds, m.fetchRepo(i))
 				}
-				return m, tea.Batch(cmds...)
+				if len(cmds) > 0 {
+					return m, tea.Batch(cmds...)
+				}
+				m.fetchingAll = false
 			}
 
 		case "s":
 			// Sync (fetch + pull) single repo
-			idx := m.selectedIndex()
-			status := m.statuses[idx]
-			if status.Fetching || status.Rebasing {
+			idx, ok := m.selectedIndex()
+			if !ok {
 				return m, nil
 			}
-			// DWIM: If no upstream, show modal to set one
-			if !status.HasUpstream && status.Error == nil {
-				return m, m.showUpstreamModal(idx, true)
-			}
-			status.Fetching = true
-			status.LastMessage = ""
-			return m, m.fetchAndPull(idx)
+			return m, m.syncRepo(idx)
 
 		case "S":
 			// Sync all repos
@@ -274,7 +755,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds := make([]tea.Cmd, 0, len(m.repos))
 				for i := range m.repos {
 					status := m.statuses[i]
-					if status.HasUpstream && status.Error == nil {
+					if status.HasUpstream && status.Error == nil && m.inBatch(i) {
 						status.Fetching = true
 						cmds = append(cmds, m.fetchAndPull(i))
 					}
@@ -285,43 +766,98 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.fetchingAll = false
 			}
 
+		case "B":
+			// Pull only repos that are strictly behind and clean
+			if !m.fetchingAll {
+				return m, m.pullBehind()
+			}
+
+		case "U":
+			// Undo the last pull, shortly after it happened
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.confirmUndoPull(idx)
+
+		case "z":
+			// Run git maintenance on the selected repo
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.runMaintenance(idx)
+
+		case "Z":
+			// Run git maintenance on all repos, after confirming
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.confirmMaintenanceAll(idx)
+
+		case "H":
+			// Fetch the full history of a shallow clone
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.unshallowRepo(idx)
+
 		case "p":
 			// Push single repo
-			idx := m.selectedIndex()
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
 			status := m.statuses[idx]
-			if status.Pushing {
+			if status.Pushing || status.RemoteOnly {
 				return m, nil
 			}
 			// If no upstream, show modal to push & set upstream
 			if !status.HasUpstream && status.Error == nil {
 				return m, m.showUpstreamModal(idx, false)
 			}
+			// Confirm the destination before pushing
 			if status.NeedsPush() {
-				status.Pushing = true
-				status.LastMessage = ""
-				return m, m.pushRepo(idx)
+				return m, m.loadPushTargets(idx)
 			}
 
 		case "P":
-			// Push all repos that need pushing
+			// Push all repos that need pushing, asking first for the ones
+			// on a protected branch
 			cmds := make([]tea.Cmd, 0)
+			var protected []int
 			for i := range m.repos {
 				status := m.statuses[i]
-				if !status.Pushing && status.NeedsPush() {
+				if !status.Pushing && status.NeedsPush() && m.inBatch(i) {
+					if m.isProtected(i) {
+						protected = append(protected, i)
+						continue
+					}
 					status.Pushing = true
 					status.LastMessage = ""
 					cmds = append(cmds, m.pushRepo(i))
 				}
 			}
+			if len(protected) > 0 {
+				cmds = append(cmds, m.confirmProtectedPushAll(protected))
+			}
 			if len(cmds) > 0 {
 				return m, tea.Batch(cmds...)
 			}
 
 		case "r":
-			// Refresh all statuses
-			cmds := make([]tea.Cmd, 0, len(m.repos))
-			for i, repo := range m.repos {
-				cmds = append(cmds, m.refreshStatus(i, repo))
+			// Refresh all statuses, and the selected repo even if manual_refresh
+			cmds := []tea.Cmd{m.refreshAll()}
+			if idx, ok := m.selectedIndex(); ok && m.repos[idx].ManualRefresh {
+				cmds = append(cmds, m.refreshStatus(idx, m.repos[idx]))
+			}
+			if m.userRefresh == nil {
+				m.userRefresh = make(map[string]bool)
+			}
+			for path := range m.refreshing {
+				m.userRefresh[path] = true
 			}
 			return m, tea.Batch(cmds...)
 
@@ -329,11 +865,193 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Toggle grouping by status
 			m.grouped = !m.grouped
 
+		case "G":
+			// Switch grouping between status and remote host. Hosts are only
+			// looked up while grouping by them, so refresh to fill them in.
+			m.groupByHost = !m.groupByHost
+			m.grouped = true
+			if m.groupByHost {
+				return m, m.refreshAll()
+			}
+
+		case "?":
+			// Toggle the help line
+			m.showHelp = !m.showHelp
+
+		case "#":
+			// Toggle abbreviated ahead/behind counts
+			m.abbreviate = !m.abbreviate
+
+		case "I":
+			// Toggle the commit subject column
+			m.hideCommits = !m.hideCommits
+
+		case "R":
+			// Stamp the selected repo as reviewed now
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			m.markReviewed(idx)
+
+		case ".":
+			// Retry the selected repo's last operation after it failed
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.retryLast(idx)
+
+		case "+":
+			// Toggle listing the repos beyond max_visible
+			m.showAll = !m.showAll
+			m.clampCursor()
+
+		case "e":
+			// Show errors panel
+			m.modalType = ModalErrors
+			m.modalScroll = 0
+
+		case "enter":
+			// Configurable action on current repo
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.runEnterAction(idx)
+
+		case "A":
+			// Amend last commit of current repo
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showAmendModal(idx)
+
+		case "y", "Y":
+			// Copy HEAD hash (short or full) to the clipboard
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			m.copyHeadHash(idx, msg.String() == "Y")
+
+		case "M":
+			// Copy a markdown table of all repo statuses to the clipboard
+			m.copyStatusTable()
+
+		case "O":
+			// Open HEAD commit on the remote web host
+			idx, ok := m.selectedIndex()
+			if !ok || m.statuses[idx].RemoteOnly || m.statuses[idx].Error != nil {
+				return m, nil
+			}
+			return m, m.openCommitInBrowser(idx)
+
+		case "c":
+			// Commit all changes in the selected repo
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showCommitModal([]int{idx})
+
+		case "T":
+			m.showThemePicker()
+
+		case "t":
+			// Tag HEAD of the selected repo
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showTagModal(idx)
+
+		case "C":
+			// Commit all changes in every dirty repo
+			return m, m.showCommitModal(m.dirtyRepos())
+
+		case "E":
+			// Edit the config file, reloading it afterwards
+			if m.adhoc {
+				m.notice = "repos are not from the config, it can't be edited here"
+				return m, nil
+			}
+			return m, tea.ExecProcess(config.EditCommand(), func(err error) tea.Msg {
+				return configEditedMsg{err: err}
+			})
+
+		case "b":
+			// Switch to a recently used branch
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showRecentBranches(idx)
+
+		case "v":
+			// Show verbatim git status
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showStatus(idx)
+
+		case "D":
+			// Fetch and show the diff stat against upstream
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showUpstreamDiff(idx)
+
+		case "<":
+			// Preview incoming commits
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showIncoming(idx)
+
+		case ">":
+			// Preview outgoing commits
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showOutgoing(idx)
+
+		case "i":
+			// Show details for current repo
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
+			return m, m.showDetail(idx)
+
+		case "1", "2", "3", "4", "5":
+			// Toggle quick status filter
+			f := filterKeys[msg.String()]
+			if m.filter == f {
+				m.filter = FilterNone
+			} else {
+				m.filter = f
+			}
+			m.clampCursor()
+
+		case "0":
+			// Clear status filter
+			m.filter = FilterNone
+			m.clampCursor()
+
 		case "u":
 			// Set upstream for current repo
-			idx := m.selectedIndex()
+			idx, ok := m.selectedIndex()
+			if !ok {
+				return m, nil
+			}
 			status := m.statuses[idx]
-			if !status.HasUpstream && status.Error == nil {
+			if !status.HasUpstream && status.Error == nil && !status.RemoteOnly {
 				return m, m.showUpstreamModal(idx, false)
 			}
 		}
@@ -347,12 +1065,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case RefreshMsg:
+		return m, m.refreshAll()
+
+	case repoChangedMsg:
+		// A change caught by a watcher replaced since is already stale
+		if msg.watcher != m.watcher {
+			return m, nil
+		}
+		cmds := []tea.Cmd{m.watcher.wait()}
+		for i, repo := range m.repos {
+			if filepath.Clean(repo.Path) == msg.path && !repo.ManualRefresh {
+				cmds = append(cmds, m.refreshStatus(i, repo))
+			}
+		}
+		return m, tea.Batch(cmds...)
+
 	case refreshTickMsg:
 		// Periodic background refresh - only if not busy
 		if !m.fetchingAll && m.modalType == ModalNone {
 			cmds := []tea.Cmd{m.scheduleRefresh()}
 			for i, repo := range m.repos {
-				if !m.statuses[i].Fetching && !m.statuses[i].Rebasing && !m.statuses[i].Pushing {
+				if m.autoRefresh(i) && !m.statuses[i].Fetching && !m.statuses[i].Rebasing && !m.statuses[i].Pushing {
 					cmds = append(cmds, m.refreshStatus(i, repo))
 				}
 			}
@@ -361,65 +1095,109 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.scheduleRefresh()
 
 	case statusUpdatedMsg:
-		if msg.index < len(m.statuses) {
-			// Preserve operation states
-			fetching := m.statuses[msg.index].Fetching
-			rebasing := m.statuses[msg.index].Rebasing
-			pushing := m.statuses[msg.index].Pushing
-			lastMsg := m.statuses[msg.index].LastMessage
-
-			m.statuses[msg.index] = msg.status
-			m.statuses[msg.index].Fetching = fetching
-			m.statuses[msg.index].Rebasing = rebasing
-			m.statuses[msg.index].Pushing = pushing
-			m.statuses[msg.index].LastMessage = lastMsg
+		// Refreshes of one repo may overlap, e.g. a periodic one and one
+		// after a fetch; it's only done refreshing once all are in
+		if m.refreshing[msg.path]--; m.refreshing[msg.path] <= 0 {
+			delete(m.refreshing, msg.path)
+			delete(m.userRefresh, msg.path)
 		}
-
-	case fetchCompleteMsg:
-		if msg.index < len(m.statuses) {
-			m.statuses[msg.index].Fetching = false
-			if msg.err != nil {
-				m.statuses[msg.index].LastMessage = formatMessage(fmt.Sprintf("fetch failed: %v", msg.err))
+		if m.resolve(&msg.repoRef) {
+			// A previously healthy repo that now fails is a new error
+			prev := m.statuses[msg.index]
+			newError := msg.status.Error != nil && prev.Error == nil && (prev.Branch != "" || prev.RemoteHead != "")
+
+			prev.update(msg.status)
+			m.checkNewCommits(msg.index)
+			// Remember the first HEAD seen for remote-only repos
+			if msg.status.RemoteOnly && msg.status.RemoteHead != "" && m.state.RemoteHeads[msg.status.Path] == "" {
+				m.markRemoteSeen(msg.index)
 			}
-		}
-		// Check if all fetches are done
-		allDone := true
-		for _, s := range m.statuses {
-			if s.Fetching {
-				allDone = false
-				break
+			// Status changes may move repos out of the active filter
+			m.clampCursor()
+			if newError {
+				return m, m.errorBell(msg.status.Error)
 			}
 		}
-		if allDone {
-			m.fetchingAll = false
+
+	case fetchCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, m.finishBulk()
+		}
+		m.logger.Log(m.repos[msg.index].Name, "fetch", msg.err)
+		m.settle(msg.index, msg.err)
+		m.finishOp(msg.index)
+		m.statuses[msg.index].Fetching = false
+		if msg.err != nil {
+			delete(m.behindBefore, m.repos[msg.index].Path)
+			setMessage(m.statuses[msg.index], fmt.Sprintf("fetch failed: %v", msg.err))
 		}
 		// Refresh status after fetch
-		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+		return m, tea.Batch(m.refreshStatus(msg.index, m.repos[msg.index]), m.errorBell(msg.err), m.finishBulk())
 
 	case pullCompleteMsg:
-		if msg.index < len(m.statuses) {
-			m.statuses[msg.index].Fetching = false
-			m.statuses[msg.index].Rebasing = false
-			if msg.err != nil {
-				m.statuses[msg.index].LastMessage = formatMessage(fmt.Sprintf("pull failed: %v", msg.err))
-			} else {
-				m.statuses[msg.index].LastMessage = formatMessage("synced")
+		if !m.resolve(&msg.repoRef) {
+			return m, m.finishBulk()
+		}
+		m.logger.Log(m.repos[msg.index].Name, "sync", msg.err)
+		m.settle(msg.index, msg.err)
+		m.finishOp(msg.index)
+		m.statuses[msg.index].Fetching = false
+		m.statuses[msg.index].Rebasing = false
+		if msg.err != nil {
+			setMessage(m.statuses[msg.index], fmt.Sprintf("pull failed: %v", msg.err))
+		} else {
+			// Only a pull that moved HEAD can be undone, back to where it was
+			if msg.before != "" && msg.after != msg.before {
+				m.statuses[msg.index].PulledAt = time.Now()
+				m.statuses[msg.index].PulledFrom = msg.before
 			}
+			setMessage(m.statuses[msg.index], "synced")
 		}
-		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+		return m, tea.Batch(m.refreshStatus(msg.index, m.repos[msg.index]), m.errorBell(msg.err), m.finishBulk())
+
+	case maintenanceCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		return m, m.maintenanceComplete(msg)
+
+	case undoCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		return m, m.undoComplete(msg)
+
+	case unshallowProgressMsg:
+		// Keep draining the progress of a repo that is gone, so git can finish
+		if !m.resolve(&msg.repoRef) {
+			return m, waitUnshallow(msg.repoRef, msg.progress, msg.done)
+		}
+		return m, m.unshallowProgress(msg)
+
+	case unshallowCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		return m, m.unshallowComplete(msg)
 
 	case pushCompleteMsg:
-		if msg.index < len(m.statuses) {
-			m.statuses[msg.index].Pushing = false
-			if msg.err != nil {
-				m.statuses[msg.index].LastMessage = formatMessage(fmt.Sprintf("push failed: %v", msg.err))
-			} else {
-				m.statuses[msg.index].LastMessage = formatMessage("pushed")
-			}
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
 		}
-		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+		m.logger.Log(m.repos[msg.index].Name, "push", msg.err)
+		m.settle(msg.index, msg.err)
+		m.statuses[msg.index].Pushing = false
+		if msg.err != nil {
+			setMessage(m.statuses[msg.index], fmt.Sprintf("push failed: %v", msg.err))
+		} else {
+			setMessage(m.statuses[msg.index], "pushed")
+		}
+		return m, tea.Batch(m.refreshStatus(msg.index, m.repos[msg.index]), m.errorBell(msg.err))
 
 	case remotesLoadedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
 		// Clear fetching state
 		m.statuses[msg.index].Fetching = false
 
@@ -427,6 +1205,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// No remotes configured - show add remote modal
 			m.modalType = ModalAddRemote
 			m.modalRepoIndex = msg.index
+			m.textInput.Placeholder = "git@github.com:user/repo.git"
 			m.textInput.Reset()
 			m.textInput.Focus()
 			return m, textinput.Blink
@@ -436,13 +1215,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var options []UpstreamOption
 		branch := m.statuses[msg.index].Branch
 
-		// First, add exact matches (remote has same branch name) - these exist
+		// Fast path: a single remote branch of the same name is the obvious pick
+		if m.cfg.AutoTrackObvious() {
+			var exact []git.RemoteBranch
+			for _, rb := range msg.branches {
+				if rb.Branch == branch {
+					exact = append(exact, rb)
+				}
+			}
+			if len(exact) == 1 {
+				return m, m.setUpstream(msg.index, exact[0].Remote, exact[0].Branch)
+			}
+		}
+
+		// First, add matching remote branches (exact names first) - these exist
+		hasExact := false
 		for _, rb := range msg.branches {
 			options = append(options, UpstreamOption{Remote: rb.Remote, Branch: rb.Branch, Exists: true})
+			hasExact = hasExact || rb.Branch == branch
 		}
 
-		// If no exact matches, suggest pushing to each remote - these need push -u
-		if len(options) == 0 {
+		// If no exact matches, suggest pushing to each remote - these need push -u.
+		// Fuzzy matches alone may well be other people's branches.
+		if !hasExact {
 			for _, remote := range msg.remotes {
 				options = append(options, UpstreamOption{Remote: remote.Name, Branch: branch, Exists: false})
 			}
@@ -453,11 +1248,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.modalCursor = 0
 		return m, nil
 
+	case pushTargetsMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		if len(msg.options) == 0 {
+			setMessage(m.statuses[msg.index], "push failed: no remotes configured")
+			return m, nil
+		}
+		// Don't replace a modal opened while the targets were loading
+		if m.modalType != ModalNone {
+			return m, nil
+		}
+		m.modalType = ModalPush
+		m.modalRepoIndex = msg.index
+		m.modalOptions = msg.options
+		m.modalCursor = 0
+		return m, nil
+
 	case upstreamSetMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		m.logger.Log(m.repos[msg.index].Name, "set-upstream", msg.err)
 		if msg.err != nil {
-			m.statuses[msg.index].LastMessage = formatMessage(fmt.Sprintf("set upstream failed: %v", msg.err))
+			setMessage(m.statuses[msg.index], fmt.Sprintf("set upstream failed: %v", msg.err))
 		} else {
-			m.statuses[msg.index].LastMessage = formatMessage("upstream set")
+			setMessage(m.statuses[msg.index], "upstream set")
 		}
 		// Refresh status and optionally continue with sync
 		refreshCmd := m.refreshStatus(msg.index, m.repos[msg.index])
@@ -468,13 +1285,140 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, refreshCmd
 
+	case textLoadedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		if m.modalType == ModalText && m.modalRepoIndex == msg.index {
+			m.textLines = msg.lines
+			m.textErr = msg.err
+			if m.textLines == nil && m.textErr == nil {
+				m.textLines = []string{}
+			}
+		}
+
+	case amendCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		m.logger.Log(m.repos[msg.index].Name, "amend", msg.err)
+		if msg.err != nil {
+			setMessage(m.statuses[msg.index], fmt.Sprintf("amend failed: %v", msg.err))
+		} else {
+			setMessage(m.statuses[msg.index], "amended")
+		}
+		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+
+	case configEditedMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("editor failed: %v", msg.err)
+			return m, nil
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			m.notice = fmt.Sprintf("config not reloaded: %v", err)
+			return m, nil
+		}
+		return m, loadRepos(cfg)
+
+	case configReloadedMsg:
+		m.notice = "reloaded " + config.ConfigPath()
+		return m, m.reloadConfig(msg.cfg, msg.repos)
+
+	case commitCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		m.commitDone(msg)
+		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+
+	case tagNameCheckedMsg:
+		if m.resolve(&msg.repoRef) {
+			m.tagNameChecked(msg)
+		}
+		return m, nil
+
+	case tagCreatedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		return m, m.tagCreated(msg)
+
+	case tagPushedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		m.tagPushed(msg)
+		return m, m.errorBell(msg.err)
+
+	case shellExitedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		if msg.err != nil {
+			setMessage(m.statuses[msg.index], fmt.Sprintf("shell failed: %v", msg.err))
+		}
+		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+
+	case branchesLoadedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		switch {
+		case msg.err != nil:
+			setMessage(m.statuses[msg.index], fmt.Sprintf("branches failed: %v", msg.err))
+		case len(msg.branches) == 0:
+			setMessage(m.statuses[msg.index], "no recently used branches")
+		default:
+			m.modalType = ModalBranches
+			m.modalRepoIndex = msg.index
+			m.modalBranches = msg.branches
+			// Start on the most recent other branch, for quick toggling
+			m.modalCursor = 0
+			if msg.branches[0] == m.statuses[msg.index].Branch && len(msg.branches) > 1 {
+				m.modalCursor = 1
+			}
+		}
+
+	case checkoutCompleteMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		m.logger.Log(m.repos[msg.index].Name, "checkout "+msg.branch, msg.err)
+		if msg.err != nil {
+			setMessage(m.statuses[msg.index], fmt.Sprintf("checkout failed: %v", msg.err))
+		} else {
+			setMessage(m.statuses[msg.index], "switched to "+msg.branch)
+		}
+		return m, m.refreshStatus(msg.index, m.repos[msg.index])
+
+	case browserOpenedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		if msg.err != nil {
+			setMessage(m.statuses[msg.index], fmt.Sprintf("open failed: %v", msg.err))
+		}
+
+	case detailLoadedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		if m.modalType == ModalDetail && m.modalRepoIndex == msg.index {
+			m.detail = &msg.detail
+		}
+
 	case remoteAddedMsg:
+		if !m.resolve(&msg.repoRef) {
+			return m, nil
+		}
+		m.logger.Log(m.repos[msg.index].Name, "add-remote", msg.err)
 		if msg.err != nil {
-			m.statuses[msg.index].LastMessage = formatMessage(fmt.Sprintf("add remote failed: %v", msg.err))
+			setMessage(m.statuses[msg.index], fmt.Sprintf("add remote failed: %v", msg.err))
 			return m, m.refreshStatus(msg.index, m.repos[msg.index])
 		}
 		// Remote added successfully - now fetch and show upstream options
-		m.statuses[msg.index].LastMessage = formatMessage("remote added")
+		setMessage(m.statuses[msg.index], "remote added")
 		m.statuses[msg.index].Fetching = true
 		return m, m.fetchThenShowUpstream(msg.index)
 	}
@@ -491,18 +1435,120 @@ func (m Model) handleModalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.textInput.Blur()
 			return m, nil
 		case "enter":
-			url := strings.TrimSpace(m.textInput.Value())
-			if url != "" {
-				m.modalType = ModalNone
-				m.textInput.Blur()
-				return m, m.addRemote(m.modalRepoIndex, "origin", url)
+			// Either "url" or "name url"
+			fields := strings.Fields(m.textInput.Value())
+			name := m.cfg.DefaultRemoteName()
+			switch len(fields) {
+			case 1:
+			case 2:
+				name = fields[0]
+				fields = fields[1:]
+			default:
+				return m, nil
+			}
+			m.modalType = ModalNone
+			m.textInput.Blur()
+			return m, m.addRemote(m.modalRepoIndex, name, fields[0])
+		default:
+			var cmd tea.Cmd
+			m.textInput, cmd = m.textInput.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.modalType == ModalAmend {
+		switch msg.String() {
+		case "esc":
+			m.modalType = ModalNone
+			m.textInput.Blur()
+			return m, nil
+		case "enter":
+			message := strings.TrimSpace(m.textInput.Value())
+			if message == "" {
+				return m, nil
+			}
+			// Unchanged subject: keep the full original message
+			if message == m.statuses[m.modalRepoIndex].CommitSubject {
+				message = ""
+			}
+			m.modalType = ModalNone
+			m.textInput.Blur()
+			return m, m.amendCommit(m.modalRepoIndex, message)
+		default:
+			var cmd tea.Cmd
+			m.textInput, cmd = m.textInput.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Scrollable read-only panels
+	if m.modalType == ModalErrors || m.modalType == ModalText {
+		switch msg.String() {
+		case "esc", "q", "e":
+			back := ModalNone
+			if m.modalType == ModalText {
+				back = m.textParent
+			}
+			m.modalType = back
+			m.textLines = nil
+		case "enter":
+			if m.modalType == ModalText && m.textConfirm != nil && m.textLines != nil {
+				confirm := m.textConfirm
+				m.modalType = ModalNone
+				m.textLines = nil
+				m.textConfirm = nil
+				return m, confirm(&m)
+			}
+		case "up", "k":
+			m.scrollModal(-1)
+		case "down", "j":
+			m.scrollModal(1)
+		}
+		return m, nil
+	}
+
+	if m.modalType == ModalBranches {
+		return m.handleBranchesKey(msg)
+	}
+
+	if m.modalType == ModalCommit {
+		return m.handleCommitKey(msg)
+	}
+
+	if m.modalType == ModalTag {
+		return m.handleTagKey(msg)
+	}
+
+	if m.modalType == ModalTheme {
+		return m.handleThemeKey(msg)
+	}
+
+	// Detail view: pick a changed file to see its diff
+	if m.modalType == ModalDetail {
+		var files []detailFile
+		if m.detail != nil {
+			files = m.detail.files
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.detailCursor > 0 {
+				m.detailCursor--
+			}
+		case "down", "j":
+			if m.detailCursor < len(files)-1 {
+				m.detailCursor++
+			}
+		case "enter":
+			if m.detailCursor < len(files) {
+				return m, m.showFileDiff(m.modalRepoIndex, files[m.detailCursor].file.Path)
 			}
-			return m, nil
-		default:
-			var cmd tea.Cmd
-			m.textInput, cmd = m.textInput.Update(msg)
-			return m, cmd
+			m.modalType = ModalNone
+			m.detail = nil
+		case "esc", "q", "i":
+			m.modalType = ModalNone
+			m.detail = nil
 		}
+		return m, nil
 	}
 
 	switch msg.String() {
@@ -533,63 +1579,275 @@ func (m Model) handleModalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.statuses[m.modalRepoIndex].Pushing = true
 			return m, m.pushWithUpstream(m.modalRepoIndex, opt.Remote, opt.Branch)
 		}
+		if m.modalType == ModalPush && len(m.modalOptions) > 0 {
+			opt := m.modalOptions[m.modalCursor]
+			m.modalType = ModalNone
+			m.modalOptions = nil
+			if m.isProtected(m.modalRepoIndex) {
+				return m, m.confirmProtectedPush(m.modalRepoIndex, opt.Remote, opt.Branch)
+			}
+			m.statuses[m.modalRepoIndex].Pushing = true
+			m.statuses[m.modalRepoIndex].LastMessage = ""
+			return m, m.pushTo(m.modalRepoIndex, opt.Remote, opt.Branch)
+		}
 	}
 
 	return m, nil
 }
 
+// markRemoteSeen records the current HEAD of a remote-only repo as seen
+func (m *Model) markRemoteSeen(index int) {
+	status := m.statuses[index]
+	if status.RemoteHead == "" {
+		return
+	}
+	m.state.RemoteHeads[status.Path] = status.RemoteHead
+	status.RemoteChanged = false
+	if err := config.SaveState(m.state); err != nil {
+		setMessage(status, fmt.Sprintf("save state failed: %v", err))
+	}
+}
+
+// moveSelected moves the selected repo up (-1) or down (1) in the list
+// and persists the new order to the config file. Only applies when ungrouped.
+func (m *Model) moveSelected(delta int) {
+	idx, ok := m.selectedIndex()
+	if !ok || m.filter != FilterNone {
+		return
+	}
+	if m.grouped {
+		setMessage(m.statuses[idx], "reorder needs grouping off (g)")
+		return
+	}
+	if m.adhoc {
+		setMessage(m.statuses[idx], "reorder needs repos from the config")
+		return
+	}
+	// Only repos listed in the config can be reordered, scanned repos follow them
+	other := idx + delta
+	if other < 0 || idx >= len(m.cfg.Repos) || other >= len(m.cfg.Repos) {
+		return
+	}
+	// Moving would misdirect results of in-flight operations
+	for _, s := range []*repoStatus{m.statuses[idx], m.statuses[other]} {
+		if s.Fetching || s.Rebasing || s.Pushing {
+			return
+		}
+	}
+
+	m.repos[idx], m.repos[other] = m.repos[other], m.repos[idx]
+	if m.marked[idx] != m.marked[other] {
+		if m.marked[idx] {
+			delete(m.marked, idx)
+			m.marked[other] = true
+		} else {
+			delete(m.marked, other)
+			m.marked[idx] = true
+		}
+	}
+	m.statuses[idx], m.statuses[other] = m.statuses[other], m.statuses[idx]
+	m.cfg.Repos[idx], m.cfg.Repos[other] = m.cfg.Repos[other], m.cfg.Repos[idx]
+	m.cursor += delta
+
+	if err := config.Save(m.cfg); err != nil {
+		setMessage(m.statuses[other], fmt.Sprintf("save order failed: %v", err))
+	}
+}
+
+// syncRepo starts fetch + pull for a repo, or offers to set an upstream first
+func (m *Model) syncRepo(index int) tea.Cmd {
+	status := m.statuses[index]
+	if status.Fetching || status.Rebasing || status.RemoteOnly {
+		return nil
+	}
+	// DWIM: If no upstream, show modal to set one
+	if !status.HasUpstream && status.Error == nil {
+		return m.showUpstreamModal(index, true)
+	}
+	status.Fetching = true
+	status.LastMessage = ""
+	return m.fetchAndPull(index)
+}
+
+// runEnterAction dispatches the Enter key according to the enter_action config
+func (m *Model) runEnterAction(index int) tea.Cmd {
+	if m.statuses[index].RemoteOnly || m.statuses[index].Error != nil {
+		return m.showDetail(index)
+	}
+	switch m.cfg.EnterAction {
+	case "shell":
+		return m.openShell(index)
+	case "sync":
+		return m.syncRepo(index)
+	case "log":
+		return m.showLog(index)
+	}
+	return m.showDetail(index)
+}
+
+// openShell suspends the UI and runs $SHELL in the repo directory
+func (m *Model) openShell(index int) tea.Cmd {
+	ref := m.ref(index)
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "sh"
+	}
+	cmd := exec.Command(shell)
+	cmd.Dir = m.repos[index].Path
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellExitedMsg{repoRef: ref, err: err}
+	})
+}
+
+// openCommitInBrowser opens the web page of the repo's HEAD commit
+func (m *Model) openCommitInBrowser(index int) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		url, err := git.CommitURL(path)
+		if err == nil {
+			err = openURL(url)
+		}
+		return browserOpenedMsg{repoRef: ref, err: err}
+	}
+}
+
+// openURL opens url with the platform's default handler
+func openURL(url string) error {
+	opener := "xdg-open"
+	switch runtime.GOOS {
+	case "darwin":
+		opener = "open"
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	}
+	return exec.Command(opener, url).Start()
+}
+
 func (m *Model) fetchRepo(index int) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
+	prune := m.cfg.FetchPrunes()
+	tags := m.repos[index].FetchTags
+	m.startOp(index)
+	m.noteBehind(index)
+	m.remember(index, "fetch", retryFetch)
 	return func() tea.Msg {
-		err := git.Fetch(path)
-		return fetchCompleteMsg{index: index, err: err}
+		err := git.Fetch(path, prune, tags)
+		return fetchCompleteMsg{repoRef: ref, err: err}
 	}
 }
 
 func (m *Model) fetchAndPull(index int) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
+	prune := m.cfg.FetchPrunes()
+	tags := m.repos[index].FetchTags
+	strategy := m.repos[index].PullStrategy
+	m.startOp(index)
+	m.remember(index, "sync", (*Model).syncRepo)
 	return func() tea.Msg {
 		// First fetch
-		if err := git.Fetch(path); err != nil {
-			return pullCompleteMsg{index: index, err: err}
+		if err := git.Fetch(path, prune, tags); err != nil {
+			return pullCompleteMsg{repoRef: ref, err: err}
 		}
-		// Then pull with rebase
-		err := git.Pull(path)
-		return pullCompleteMsg{index: index, err: err}
+		// Then pull with the repo's strategy, noting where HEAD was for undo
+		before, _ := git.Head(path)
+		err := git.Pull(path, strategy)
+		after, _ := git.Head(path)
+		return pullCompleteMsg{repoRef: ref, before: before, after: after, err: err}
 	}
 }
 
 func (m *Model) pushRepo(index int) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
+	m.remember(index, "push", retryPush)
 	return func() tea.Msg {
 		err := git.Push(path)
-		return pushCompleteMsg{index: index, err: err}
+		return pushCompleteMsg{repoRef: ref, err: err}
 	}
 }
 
+// upstreamCandidates returns remote branches offered as upstream for branch,
+// optionally including ones whose names only share a suffix with it
+func upstreamCandidates(path, branch string, similar bool) []git.RemoteBranch {
+	var branches []git.RemoteBranch
+	if similar {
+		branches, _ = git.ListSimilarRemoteBranches(path, branch)
+	} else {
+		branches, _ = git.ListRemoteBranches(path, branch)
+	}
+	return branches
+}
+
 func (m *Model) loadRemotesForUpstream(index int) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	branch := m.statuses[index].Branch
+	similar := m.cfg.FuzzyUpstreamMatching()
+	return func() tea.Msg {
+		remotes, _ := git.ListRemotes(path)
+		branches := upstreamCandidates(path, branch, similar)
+		return remotesLoadedMsg{repoRef: ref, remotes: remotes, branches: branches}
+	}
+}
+
+// loadPushTargets resolves where the current branch would be pushed. When
+// there's no single destination, every remote is offered instead.
+func (m *Model) loadPushTargets(index int) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
 	branch := m.statuses[index].Branch
 	return func() tea.Msg {
+		if remote, target, err := git.PushDestination(path); err == nil {
+			return pushTargetsMsg{repoRef: ref, options: []UpstreamOption{{Remote: remote, Branch: target, Exists: true}}}
+		}
 		remotes, _ := git.ListRemotes(path)
-		branches, _ := git.ListRemoteBranches(path, branch)
-		return remotesLoadedMsg{index: index, remotes: remotes, branches: branches}
+		var options []UpstreamOption
+		for _, r := range remotes {
+			options = append(options, UpstreamOption{Remote: r.Name, Branch: branch})
+		}
+		return pushTargetsMsg{repoRef: ref, options: options}
+	}
+}
+
+func (m *Model) pushTo(index int, remote, branch string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	m.remember(index, "push", func(m *Model, index int) tea.Cmd {
+		if !m.beginPush(index) {
+			return nil
+		}
+		return m.pushTo(index, remote, branch)
+	})
+	return func() tea.Msg {
+		err := git.PushTo(path, remote, branch)
+		return pushCompleteMsg{repoRef: ref, err: err}
 	}
 }
 
 func (m *Model) setUpstream(index int, remote, branch string) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
 	return func() tea.Msg {
 		err := git.SetUpstream(path, remote, branch)
-		return upstreamSetMsg{index: index, err: err}
+		return upstreamSetMsg{repoRef: ref, err: err}
 	}
 }
 
 func (m *Model) pushWithUpstream(index int, remote, branch string) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
+	m.remember(index, "push", func(m *Model, index int) tea.Cmd {
+		if !m.beginPush(index) {
+			return nil
+		}
+		return m.pushWithUpstream(index, remote, branch)
+	})
 	return func() tea.Msg {
 		err := git.PushWithUpstream(path, remote, branch)
-		return pushCompleteMsg{index: index, err: err}
+		return pushCompleteMsg{repoRef: ref, err: err}
 	}
 }
 
@@ -600,31 +1858,86 @@ func (m *Model) showUpstreamModal(index int, afterSetup bool) tea.Cmd {
 	return m.loadRemotesForUpstream(index)
 }
 
+// copyHeadHash copies the repo's HEAD hash to the clipboard and reports the
+// result in the repo's message column
+func (m *Model) copyHeadHash(index int, full bool) {
+	status := m.statuses[index]
+	hash := status.HeadHash
+	if status.RemoteOnly {
+		hash = status.RemoteHead
+	}
+	if hash == "" {
+		return
+	}
+	if !full && len(hash) > 7 {
+		hash = hash[:7]
+	}
+	if err := clipboard.WriteAll(hash); err != nil {
+		setMessage(status, fmt.Sprintf("copy failed: %v", err))
+		return
+	}
+	setMessage(status, "copied "+hash)
+}
+
+// showAmendModal opens the amend prompt prefilled with the current subject,
+// refusing when the last commit has already been pushed
+func (m *Model) showAmendModal(index int) tea.Cmd {
+	status := m.statuses[index]
+	if status.Error != nil || status.RemoteOnly || status.CommitSubject == "" {
+		return nil
+	}
+	if status.HasUpstream && status.Unpushed() == 0 {
+		setMessage(status, "amend failed: last commit is already pushed")
+		return nil
+	}
+	m.modalType = ModalAmend
+	m.modalRepoIndex = index
+	m.textInput.Placeholder = "commit message"
+	m.textInput.SetValue(status.CommitSubject)
+	m.textInput.CursorEnd()
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+func (m *Model) amendCommit(index int, message string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		err := git.AmendCommit(path, message)
+		return amendCompleteMsg{repoRef: ref, err: err}
+	}
+}
+
 func (m *Model) addRemote(index int, name, url string) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
 	return func() tea.Msg {
 		err := git.AddRemote(path, name, url)
-		return remoteAddedMsg{index: index, err: err}
+		return remoteAddedMsg{repoRef: ref, err: err}
 	}
 }
 
 func (m *Model) fetchThenShowUpstream(index int) tea.Cmd {
+	ref := m.ref(index)
 	path := m.repos[index].Path
 	branch := m.statuses[index].Branch
+	similar := m.cfg.FuzzyUpstreamMatching()
+	prune := m.cfg.FetchPrunes()
+	tags := m.repos[index].FetchTags
 	return func() tea.Msg {
 		// Fetch from the new remote
-		if err := git.Fetch(path); err != nil {
-			return remotesLoadedMsg{index: index, remotes: nil, branches: nil}
+		if err := git.Fetch(path, prune, tags); err != nil {
+			return remotesLoadedMsg{repoRef: ref, remotes: nil, branches: nil}
 		}
 		// Now load remotes and branches
 		remotes, _ := git.ListRemotes(path)
-		branches, _ := git.ListRemoteBranches(path, branch)
-		return remotesLoadedMsg{index: index, remotes: remotes, branches: branches}
+		branches := upstreamCandidates(path, branch, similar)
+		return remotesLoadedMsg{repoRef: ref, remotes: remotes, branches: branches}
 	}
 }
 
 func (m Model) View() string {
-	if m.quitting {
+	if m.quitting && !m.inline {
 		return ""
 	}
 
@@ -643,24 +1956,73 @@ func (m Model) View() string {
 		return m.renderModal(width)
 	}
 
+	// Each grid column gets an equal share of the width
+	cols := m.columnCount()
+	cellWidth := (innerWidth - (cols-1)*columnGap) / cols
+
 	// Calculate column widths
 	maxNameLen := 0
 	maxBranchLen := 0
+	tracking := m.cfg.TrackingVisible()
 	for _, s := range m.statuses {
-		if len(s.Name) > maxNameLen {
-			maxNameLen = len(s.Name)
+		if w := runewidth.StringWidth(s.Name); w > maxNameLen {
+			maxNameLen = w
 		}
-		if len(s.Branch) > maxBranchLen {
-			maxBranchLen = len(s.Branch)
+		if w := runewidth.StringWidth(branchLabel(s, tracking)); w > maxBranchLen {
+			maxBranchLen = w
 		}
 	}
-	if maxBranchLen > 14 {
-		maxBranchLen = 14
+	// The tracked remote gets more room on wide terminals
+	branchCap := 14
+	if tracking {
+		branchCap = max(14, min(30, cellWidth/4))
+	}
+	// Without commit subjects, branches get the room they left
+	if m.hideCommits {
+		branchCap = max(branchCap, cellWidth/3)
+	}
+	if maxBranchLen > branchCap {
+		maxBranchLen = branchCap
+	}
+	// Host column only appears when grouping by host
+	order := m.displayOrder()
+	hostWidth := 0
+	if m.grouped && m.groupByHost {
+		for _, i := range order {
+			hostWidth = max(hostWidth, runewidth.StringWidth(hostSection(m.statuses[i])))
+		}
+		hostWidth = min(hostWidth, 20)
+	}
+
+	// Icon column only appears when some repo has an icon
+	iconWidth := 0
+	for _, repo := range m.repos {
+		if w := lipgloss.Width(repo.Icon); w > iconWidth {
+			iconWidth = w
+		}
 	}
 
+	// Alias column only appears when some repo has an alias
+	aliasWidth := 0
+	for _, repo := range m.repos {
+		if w := runewidth.StringWidth(repo.Alias); w > aliasWidth {
+			aliasWidth = w
+		}
+	}
+
+	// Column for status_command output, sized to the widest
+	customWidth := 0
+	for _, s := range m.statuses {
+		customWidth = max(customWidth, runewidth.StringWidth(s.Custom))
+	}
+	customWidth = min(customWidth, statusCommandWidth)
+
+	// Glyphs may be configured wider than one cell
+	cursorWidth := lipgloss.Width(m.glyphs.Cursor)
+	markWidth := lipgloss.Width(m.glyphs.Mark)
+
 	// Build repo lines
 	var lines []string
-	order := m.displayOrder()
 	for displayIdx, repoIdx := range order {
 		status := m.statuses[repoIdx]
 		isSelected := displayIdx == m.cursor
@@ -669,25 +2031,63 @@ func (m Model) View() string {
 
 		// Cursor
 		if isSelected {
-			parts = append(parts, lipgloss.NewStyle().Foreground(t.Selected).Render("▸"))
+			parts = append(parts, lipgloss.NewStyle().Foreground(t.Selected).Render(m.glyphs.Cursor))
 		} else {
-			parts = append(parts, " ")
+			parts = append(parts, strings.Repeat(" ", cursorWidth))
+		}
+
+		// Mark column only appears while repos are marked
+		if len(m.marked) > 0 {
+			if m.marked[repoIdx] {
+				parts = append(parts, lipgloss.NewStyle().Bold(true).Foreground(t.Selected).Render(m.glyphs.Mark))
+			} else {
+				parts = append(parts, strings.Repeat(" ", markWidth))
+			}
+		}
+
+		// Host, named on the first repo of each section
+		if hostWidth > 0 {
+			host := hostSection(status)
+			if displayIdx > 0 && hostSection(m.statuses[order[displayIdx-1]]) == host {
+				host = ""
+			}
+			parts = append(parts, lipgloss.NewStyle().Bold(true).Foreground(t.Title).Render(pad(truncate(host, hostWidth), hostWidth)))
 		}
 
-		// Name
-		name := fmt.Sprintf("%-*s", maxNameLen, status.Name)
+		// Icon
+		repo := m.repos[repoIdx]
+		if iconWidth > 0 {
+			parts = append(parts, pad(repo.Icon, iconWidth))
+		}
+
+		// Alias
+		if aliasWidth > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(t.HelpText).Render(pad(repo.Alias, aliasWidth)))
+		}
+
+		// Name (per-repo color overrides the theme), dimmed while its status
+		// is recomputed
+		name := pad(status.Name, maxNameLen)
+		nameColor := t.RepoName
+		if repo.Color != "" {
+			nameColor = lipgloss.Color(repo.Color)
+		}
+		if m.userRefresh[repo.Path] && m.isLoaded(repoIdx) {
+			nameColor = t.Dim
+		}
 		if isSelected {
 			parts = append(parts, lipgloss.NewStyle().Bold(true).Foreground(t.Selected).Render(name))
 		} else {
-			parts = append(parts, lipgloss.NewStyle().Foreground(t.RepoName).Render(name))
+			parts = append(parts, lipgloss.NewStyle().Foreground(nameColor).Render(name))
 		}
 
 		// Branch
-		branch := status.Branch
-		if len(branch) > maxBranchLen {
-			branch = branch[:maxBranchLen-1] + "…"
+		// Drop the tracked remote before cutting into the branch name itself
+		branchText := branchLabel(status, tracking)
+		if runewidth.StringWidth(branchText) > maxBranchLen {
+			branchText = status.HeadLabel()
 		}
-		branchStr := fmt.Sprintf("%-*s", maxBranchLen, branch)
+		branchStr := pad(truncate(branchText, maxBranchLen), maxBranchLen)
 		parts = append(parts, lipgloss.NewStyle().Foreground(t.Branch).Render(branchStr))
 
 		// Dirty
@@ -698,74 +2098,158 @@ func (m Model) View() string {
 		}
 
 		// Status
-		statusWidth := 12
+		statusWidth := 13 // Fits "not refreshed"
 		var statusStr string
-		if status.Error != nil {
-			errMsg := status.Error.Error()
-			if len(errMsg) > statusWidth-2 {
-				errMsg = errMsg[:statusWidth-3] + "…"
+		if !m.isLoaded(repoIdx) {
+			placeholder := "…"
+			if repo.ManualRefresh {
+				placeholder = "not refreshed"
 			}
-			statusStr = lipgloss.NewStyle().Foreground(t.Error).Render(fmt.Sprintf("✗ %-*s", statusWidth-2, errMsg))
+			statusStr = lipgloss.NewStyle().Foreground(t.Dim).Render(pad(placeholder, statusWidth))
+		} else if status.Error != nil {
+			glyph := m.glyphs.Error + " "
+			msgWidth := statusWidth - lipgloss.Width(glyph)
+			errMsg := pad(truncate(status.Error.Error(), msgWidth), msgWidth)
+			statusStr = lipgloss.NewStyle().Foreground(t.Error).Render(glyph + errMsg)
 		} else if status.Fetching {
-			statusStr = lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" fetch…")
-			statusStr = fmt.Sprintf("%-*s", statusWidth, statusStr)
+			statusStr = pad(lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" fetch…"), statusWidth)
 		} else if status.Rebasing {
-			statusStr = lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" rebase…")
-			statusStr = fmt.Sprintf("%-*s", statusWidth, statusStr)
+			statusStr = pad(lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" rebase…"), statusWidth)
 		} else if status.Pushing {
-			statusStr = lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" push…")
-			statusStr = fmt.Sprintf("%-*s", statusWidth, statusStr)
+			statusStr = pad(lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" push…"), statusWidth)
+		} else if status.Maintaining {
+			statusStr = pad(lipgloss.NewStyle().Foreground(t.Spinner).Render(m.spinner.View()+" tidy…"), statusWidth)
+		} else if status.RemoteChanged {
+			statusStr = lipgloss.NewStyle().Bold(true).Foreground(t.Behind).Render(pad(m.glyphs.News+" news", statusWidth))
+		} else if status.RemoteOnly {
+			statusStr = lipgloss.NewStyle().Foreground(t.NoRemote).Render(pad(m.glyphs.Remote+" remote", statusWidth))
 		} else if !status.HasUpstream {
-			statusStr = lipgloss.NewStyle().Foreground(t.NoRemote).Render(fmt.Sprintf("%-*s", statusWidth, "○ no upstream"))
+			statusStr = lipgloss.NewStyle().Foreground(t.NoRemote).Render(pad(m.glyphs.NoUpstream+" no upstream", statusWidth))
 		} else if status.IsSynced() {
-			statusStr = lipgloss.NewStyle().Bold(true).Foreground(t.Synced).Render(fmt.Sprintf("%-*s", statusWidth, "✓ synced"))
+			statusStr = lipgloss.NewStyle().Bold(true).Foreground(t.Synced).Render(pad(m.glyphs.Synced+" synced", statusWidth))
 		} else {
 			var statusParts []string
-			if status.Ahead > 0 {
-				statusParts = append(statusParts, lipgloss.NewStyle().Bold(true).Foreground(t.Ahead).Render(fmt.Sprintf("↑%d", status.Ahead)))
+			if n := status.Unpushed(); n > 0 {
+				arrow := m.glyphs.Ahead
+				if status.PushRef != "" {
+					arrow = m.glyphs.PushAhead
+				}
+				if m.staleUnpushed(status) {
+					statusParts = append(statusParts, lipgloss.NewStyle().Bold(true).Foreground(t.Error).Render(arrow+m.formatCount(n)+"!"))
+				} else {
+					statusParts = append(statusParts, lipgloss.NewStyle().Bold(true).Foreground(t.Ahead).Render(arrow+m.formatCount(n)))
+				}
 			}
 			if status.Behind > 0 {
-				statusParts = append(statusParts, lipgloss.NewStyle().Bold(true).Foreground(t.Behind).Render(fmt.Sprintf("↓%d", status.Behind)))
-			}
-			statusStr = strings.Join(statusParts, " ")
-			// Pad to fixed width
-			visWidth := lipgloss.Width(statusStr)
-			if visWidth < statusWidth {
-				statusStr += strings.Repeat(" ", statusWidth-visWidth)
+				statusParts = append(statusParts, lipgloss.NewStyle().Bold(true).Foreground(t.Behind).Render(m.glyphs.Behind+m.formatCount(status.Behind)))
 			}
+			statusStr = pad(strings.Join(statusParts, " "), statusWidth)
 		}
 		parts = append(parts, statusStr)
 
+		if customWidth > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(t.HelpText).Render(pad(truncate(status.Custom, customWidth), customWidth)))
+		}
+
 		// Commit info or last message - use remaining space
-		usedWidth := 1 + 1 + maxNameLen + 1 + maxBranchLen + 1 + 1 + statusWidth + 2
-		remainingWidth := innerWidth - usedWidth
+		usedWidth := cursorWidth + 1 + maxNameLen + 1 + maxBranchLen + 1 + 1 + statusWidth + 2
+		if iconWidth > 0 {
+			usedWidth += iconWidth + 1
+		}
+		if hostWidth > 0 {
+			usedWidth += hostWidth + 1
+		}
+		if aliasWidth > 0 {
+			usedWidth += aliasWidth + 1
+		}
+		if customWidth > 0 {
+			usedWidth += customWidth + 1
+		}
+		if len(m.marked) > 0 {
+			usedWidth += markWidth + 1
+		}
+		remainingWidth := cellWidth - usedWidth
 		if remainingWidth > 10 && status.Error == nil {
+			// Whether the upstream remote answered, when checked
+			switch status.RemoteReachable {
+			case git.Reachable:
+				parts = append(parts, lipgloss.NewStyle().Foreground(t.Synced).Render(m.glyphs.Reachable))
+				remainingWidth -= lipgloss.Width(m.glyphs.Reachable) + 1
+			case git.Unreachable:
+				parts = append(parts, lipgloss.NewStyle().Foreground(t.Error).Render(m.glyphs.Reachable))
+				remainingWidth -= lipgloss.Width(m.glyphs.Reachable) + 1
+			}
+			// Informational clone flags
+			var flags []string
+			if status.Sparse {
+				flags = append(flags, "sparse")
+			}
+			if status.PartialClone {
+				flags = append(flags, "partial")
+			}
+			if status.Shallow {
+				flags = append(flags, "shallow")
+			}
+			if n := status.Submodules; n > 0 {
+				flags = append(flags, fmt.Sprintf("%d submodules", n))
+			}
+			if status.Locked != "" {
+				flags = append(flags, "locked")
+			}
+			if len(flags) > 0 {
+				tag := lipgloss.NewStyle().Foreground(t.NoRemote).Render("[" + strings.Join(flags, ",") + "]")
+				parts = append(parts, tag)
+				remainingWidth -= lipgloss.Width(tag) + 1
+			}
+			// Commits the last fetch brought in
+			if badge := m.newCommitsBadge(repoIdx); badge != "" {
+				badge = lipgloss.NewStyle().Bold(true).Foreground(t.Behind).Render(badge)
+				parts = append(parts, badge)
+				remainingWidth -= lipgloss.Width(badge) + 1
+			}
+			// Time since the repo was last marked reviewed
+			if label := m.reviewedLabel(repoIdx); label != "" {
+				reviewed := lipgloss.NewStyle().Foreground(t.HelpText).Render(label)
+				parts = append(parts, reviewed)
+				remainingWidth -= lipgloss.Width(reviewed) + 1
+			}
+			// Recent activity, e.g. 3 today
+			if since := m.cfg.CommitsSince; since != "" && status.HeadHash != "" {
+				label := "since " + since
+				if since == "midnight" {
+					label = "today"
+				}
+				count := lipgloss.NewStyle().Foreground(t.HelpText).Render(fmt.Sprintf("%d %s", status.RecentCommits, label))
+				parts = append(parts, count)
+				remainingWidth -= lipgloss.Width(count) + 1
+			}
+			// Size of uncommitted work, e.g. +42 -7
+			if status.Insertions > 0 || status.Deletions > 0 {
+				stat := lipgloss.NewStyle().Foreground(t.Synced).Render(fmt.Sprintf("+%d", status.Insertions)) + " " +
+					lipgloss.NewStyle().Foreground(t.Error).Render(fmt.Sprintf("-%d", status.Deletions))
+				parts = append(parts, stat)
+				remainingWidth -= lipgloss.Width(stat) + 1
+			}
 			if status.LastMessage != "" {
 				// Show last operation message (errors, sync status, etc.)
-				msg := status.LastMessage
-				if len(msg) > remainingWidth {
-					msg = msg[:remainingWidth-1] + "…"
-				}
+				// Multi-line git errors would break the row
+				msg := truncate(strings.Join(strings.Fields(status.LastMessage), " "), remainingWidth)
 				// Use error color for failure messages, dim for success
 				msgStyle := lipgloss.NewStyle().Foreground(t.Dim)
 				if strings.Contains(status.LastMessage, "failed") {
 					msgStyle = lipgloss.NewStyle().Foreground(t.Error)
 				}
 				parts = append(parts, msgStyle.Render(msg))
-			} else if status.CommitSubject != "" {
-				age := status.CommitAge
-				// Shorten age
-				ageParts := strings.Split(age, " ")
-				if len(ageParts) >= 2 {
-					age = ageParts[0] + string(ageParts[1][0])
-				}
-				ageWidth := 5
+			} else if status.RemoteOnly && len(status.RemoteHead) >= 7 {
+				parts = append(parts, lipgloss.NewStyle().Foreground(t.Dim).Render("HEAD "+status.RemoteHead[:7]))
+			} else if status.CommitSubject != "" && !m.hideCommits {
+				age := humanizeAge(status.CommitTime)
 				subjectWidth := remainingWidth - ageWidth - 1
+				if limit := m.cfg.CommitSubjectWidth; limit > 0 && subjectWidth > limit {
+					subjectWidth = limit
+				}
 				if subjectWidth > 0 {
-					subject := status.CommitSubject
-					if len(subject) > subjectWidth {
-						subject = subject[:subjectWidth-1] + "…"
-					}
+					subject := truncate(status.CommitSubject, subjectWidth)
 					commitInfo := fmt.Sprintf("%*s %s", ageWidth, age, subject)
 					parts = append(parts, lipgloss.NewStyle().Foreground(t.Dim).Render(commitInfo))
 				}
@@ -775,17 +2259,12 @@ func (m Model) View() string {
 		line := strings.Join(parts, " ")
 		lines = append(lines, line)
 	}
+	if len(lines) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Dim).Render(
+			fmt.Sprintf("No repos match filter %q", m.filter.Label())))
+	}
 
 	// Build help line
-	helpItems := []struct{ key, desc string }{
-		{"f/F", "fetch"},
-		{"s/S", "sync"},
-		{"p/P", "push"},
-		{"u", "upstream"},
-		{"r", "refresh"},
-		{"g", "group"},
-		{"q", "quit"},
-	}
 	var helpParts []string
 	for _, item := range helpItems {
 		key := lipgloss.NewStyle().Bold(true).Foreground(t.HelpKey).Render(item.key)
@@ -795,7 +2274,16 @@ func (m Model) View() string {
 	helpLine := strings.Join(helpParts, "  ")
 
 	// Combine content
+	lines = gridLines(lines, cols, cellWidth)
+	if height := m.listHeight(); height > 0 {
+		lines = scrollLines(lines, m.listOffset, height)
+	}
+	if hidden := m.hiddenCount(); hidden > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Dim).Render(
+			fmt.Sprintf("… and %d more (+ to show all)", hidden)))
+	}
 	content := strings.Join(lines, "\n")
+	summaryLine := lipgloss.NewStyle().Foreground(t.HelpText).Render(m.summary())
 
 	// Create box style
 	boxStyle := lipgloss.NewStyle().
@@ -814,13 +2302,251 @@ func (m Model) View() string {
 	var b strings.Builder
 	b.WriteString("\n")
 
-	innerContent := titleStyle.Render("gitpulse") + "\n\n" + content + "\n\n" + helpLine
+	innerContent := titleStyle.Render("gitpulse") + "\n\n" + content + "\n\n" + summaryLine
+	if m.showHelp {
+		innerContent += "\n" + helpLine
+	}
 	b.WriteString(boxStyle.Render(innerContent))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// staleUnpushed reports whether a repo has unpushed commits whose last commit
+// is older than the configured threshold
+func (m Model) staleUnpushed(s *repoStatus) bool {
+	after := m.cfg.UnpushedStaleAfter()
+	return after > 0 && s.NeedsPush() && s.CommitTime > 0 &&
+		time.Since(time.Unix(s.CommitTime, 0)) > after
+}
+
+// helpItems are the keys listed in the help line
+var helpItems = []struct{ key, desc string }{
+	{"f/F", "fetch"},
+	{"s/S", "sync"},
+	{"p/P", "push"},
+	{"i", "details"},
+	{"u", "upstream"},
+	{"r", "refresh"},
+	{"e", "errors"},
+	{"g", "group"},
+	{"'", "jump"},
+	{"x", "mark"},
+	{"1-5", "filter"},
+	{"?", "hide help"},
+	{"q", "quit"},
+}
+
+// ageWidth is the widest humanizeAge result
+const ageWidth = 3
+
+// humanizeAge renders how long ago a Unix time was in fixed buckets: now,
+// 5m, 3h, 2d, 4w, 1y. Results are at most ageWidth wide (short of a
+// century), so the column stays aligned.
+func humanizeAge(t int64) string {
+	if t == 0 {
+		return ""
+	}
+	d := time.Since(time.Unix(t, 0))
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dw", int(d/(7*24*time.Hour)))
+	}
+	return fmt.Sprintf("%dy", int(d/(365*24*time.Hour)))
+}
+
+// formatCount renders an ahead/behind count, rounding large values down to
+// their leading digit (347 → 300+) when abbreviation is on
+func (m Model) formatCount(n int) string {
+	if !m.abbreviate || n <= m.cfg.AbbreviateThreshold() {
+		return strconv.Itoa(n)
+	}
+	step := 1
+	for n/step >= 10 {
+		step *= 10
+	}
+	return fmt.Sprintf("%d+", n/step*step)
+}
+
+// branchLabel returns the branch column text, optionally followed by the
+// tracked remote (e.g. main → origin). The upstream branch is only named when
+// it differs from the local one.
+func branchLabel(s *repoStatus, tracking bool) string {
+	if !tracking || !s.HasUpstream || s.Upstream == "" {
+		return s.HeadLabel()
+	}
+	remote, branch, ok := strings.Cut(s.Upstream, "/")
+	if !ok || branch != s.Branch {
+		return s.Branch + " → " + s.Upstream
+	}
+	return s.Branch + " → " + remote
+}
+
+// truncate shortens s to at most width terminal cells, marking the cut with
+// an ellipsis. Never splits multibyte characters.
+func truncate(s string, width int) string {
+	return runewidth.Truncate(s, width, "…")
+}
+
+// pad right-pads s with spaces to the given display width, ignoring ANSI
+// escapes. Strings already at least width wide are returned as is.
+func pad(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// countLabels returns the non-zero counts, e.g. 3 behind
+func (m Model) countLabels() []string {
+	behind, ahead, dirty, errors := m.counts()
+	var labels []string
+	for _, c := range []struct {
+		n     int
+		label string
+	}{
+		{behind, "behind"},
+		{ahead, "ahead"},
+		{dirty, "dirty"},
+		{errors, "errors"},
+	} {
+		if c.n > 0 {
+			labels = append(labels, fmt.Sprintf("%d %s", c.n, c.label))
+		}
+	}
+	return labels
+}
+
+// counts returns how many repos are behind, ahead, dirty and failing
+func (m Model) counts() (behind, ahead, dirty, errors int) {
+	for _, s := range m.statuses {
+		if s.NeedsPull() {
+			behind++
+		}
+		if s.NeedsPush() {
+			ahead++
+		}
+		if s.Dirty {
+			dirty++
+		}
+		if s.Error != nil {
+			errors++
+		}
+	}
+	return behind, ahead, dirty, errors
+}
+
+// summary returns a one-line overview of all repos and the active filter,
+// rendered from footer_template when one is configured
+func (m Model) summary() string {
+	behind, ahead, dirty, errors := m.counts()
+
+	var parts []string
+	if tmpl := m.cfg.FooterTemplate; tmpl != "" {
+		theme := m.cfg.Theme
+		if _, ok := Themes[theme]; !ok {
+			theme = DefaultTheme
+		}
+		parts = append(parts, strings.NewReplacer(
+			"{repos}", strconv.Itoa(len(m.statuses)),
+			"{behind}", strconv.Itoa(behind),
+			"{ahead}", strconv.Itoa(ahead),
+			"{dirty}", strconv.Itoa(dirty),
+			"{errors}", strconv.Itoa(errors),
+			"{theme}", theme,
+		).Replace(tmpl))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d repos", len(m.statuses)))
+		parts = append(parts, m.countLabels()...)
+	}
+	if m.filter != FilterNone {
+		parts = append(parts, fmt.Sprintf("filter: %s (0 to clear)", m.filter.Label()))
+	}
+	if n := len(m.marked); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d marked (X to clear)", n))
+	}
+	if eta, ok := m.bulkETA(); ok {
+		parts = append(parts, formatETA(eta))
+	}
+	if n := len(m.userRefresh); n > 0 {
+		parts = append(parts, fmt.Sprintf("%s refreshing %d", m.spinner.View(), n))
+	}
+	if m.notice != "" {
+		parts = append(parts, m.notice)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// wideModalWidth returns the width for modals showing long content
+func wideModalWidth(width int) int {
+	if width-10 > 100 {
+		return 100
+	}
+	return width - 10
+}
+
+// modalLines returns the full body of a scrollable modal
+func (m Model) modalLines() []string {
+	width := m.width
+	if width < 60 {
+		width = 80
+	}
+	wrapWidth := wideModalWidth(width) - 6 // border + padding
+	switch m.modalType {
+	case ModalErrors:
+		return m.renderErrors(wrapWidth)
+	case ModalText:
+		return m.renderText(wrapWidth)
+	}
+	return nil
+}
+
+// modalBodyHeight returns how many body lines fit in a scrollable modal
+func (m Model) modalBodyHeight() int {
+	if m.height == 0 {
+		return 20
+	}
+	// Leave room for margins, border, padding, title and help
+	if h := m.height - 12; h > 5 {
+		return h
+	}
+	return 5
+}
+
+// scrollModal moves the modal scroll offset, keeping the last page in view
+func (m *Model) scrollModal(delta int) {
+	m.modalScroll += delta
+	if max := len(m.modalLines()) - m.modalBodyHeight(); m.modalScroll > max {
+		m.modalScroll = max
+	}
+	if m.modalScroll < 0 {
+		m.modalScroll = 0
+	}
+}
+
+// scrollLines returns the window of lines starting at offset that fits in height
+func scrollLines(lines []string, offset, height int) []string {
+	if max := len(lines) - height; offset > max {
+		offset = max
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	end := offset + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return lines[offset:end]
+}
+
 func (m Model) renderModal(width int) string {
 	t := m.theme
 
@@ -845,10 +2571,9 @@ func (m Model) renderModal(width int) string {
 		lines = append(lines, "")
 
 		for i, opt := range m.modalOptions {
-			cursor := "  "
+			cursor := cursorGlyph(m.glyphs, i == m.modalCursor)
 			style := lipgloss.NewStyle().Foreground(t.RepoName)
 			if i == m.modalCursor {
-				cursor = "▸ "
 				style = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
 			}
 			var optStr string
@@ -869,12 +2594,96 @@ func (m Model) renderModal(width int) string {
 
 		var lines []string
 		lines = append(lines, lipgloss.NewStyle().Foreground(t.Dim).Render(
-			"No remotes configured. Add origin:"))
+			fmt.Sprintf("No remotes configured. Add %s (or enter \"name url\"):", m.cfg.DefaultRemoteName())))
 		lines = append(lines, "")
 		lines = append(lines, m.textInput.View())
 
 		content = strings.Join(lines, "\n")
 		helpText = "⏎ add remote  esc cancel"
+
+	case ModalPush:
+		status := m.statuses[m.modalRepoIndex]
+		title = fmt.Sprintf("Push %s", status.Name)
+
+		var lines []string
+		note := fmt.Sprintf("Branch: %s (%s%d)", status.Branch, m.glyphs.Ahead, status.Unpushed())
+		if len(m.modalOptions) > 1 {
+			note += ", no single push destination"
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Dim).Render(note))
+		lines = append(lines, "")
+
+		for i, opt := range m.modalOptions {
+			cursor := cursorGlyph(m.glyphs, i == m.modalCursor)
+			style := lipgloss.NewStyle().Foreground(t.RepoName)
+			if i == m.modalCursor {
+				style = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+			}
+			lines = append(lines, cursor+style.Render(fmt.Sprintf("push to %s/%s", opt.Remote, opt.Branch)))
+		}
+
+		content = strings.Join(lines, "\n")
+		helpText = "↑/↓ select  ⏎ push  esc cancel"
+
+	case ModalAmend:
+		status := m.statuses[m.modalRepoIndex]
+		title = fmt.Sprintf("Amend last commit of %s", status.Name)
+
+		note := "Not pushed yet. Staged changes are included."
+		if !status.HasUpstream {
+			note = "No upstream: make sure this commit isn't shared."
+		}
+		var lines []string
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Dim).Render(note))
+		lines = append(lines, "")
+		lines = append(lines, m.textInput.View())
+
+		content = strings.Join(lines, "\n")
+		helpText = "⏎ amend  esc cancel"
+		modalWidth = wideModalWidth(width)
+
+	case ModalCommit:
+		title, content = m.renderCommit()
+		helpText = "⏎ commit  esc cancel"
+		modalWidth = wideModalWidth(width)
+
+	case ModalTag:
+		title, content = m.renderTag()
+		helpText = "⏎ next  esc cancel"
+		if m.tagName != "" {
+			helpText = "⏎ tag  esc cancel"
+		}
+		modalWidth = wideModalWidth(width)
+
+	case ModalTheme:
+		title = "Theme"
+		content = m.renderThemePicker()
+		helpText = "↑/↓ preview  ⏎ save  esc cancel"
+
+	case ModalBranches:
+		title = m.branchesTitle()
+		content = m.renderBranches()
+		helpText = "↑/↓ select  ⏎ checkout  esc cancel"
+
+	case ModalDetail:
+		title, content = m.renderDetail()
+		helpText = "esc close"
+		if m.detail != nil && len(m.detail.files) > 0 {
+			helpText = "↑/↓ file  ⏎ diff  esc close"
+		}
+		modalWidth = wideModalWidth(width)
+
+	case ModalErrors, ModalText:
+		title = m.textTitle
+		if m.modalType == ModalErrors {
+			title = m.errorsTitle()
+		}
+		content = strings.Join(scrollLines(m.modalLines(), m.modalScroll, m.modalBodyHeight()), "\n")
+		helpText = "↑/↓ scroll  esc close"
+		if m.modalType == ModalText && m.textConfirm != nil {
+			helpText = fmt.Sprintf("↑/↓ scroll  ⏎ %s  esc close", m.textConfirmLabel)
+		}
+		modalWidth = wideModalWidth(width)
 	}
 
 	// Build modal box
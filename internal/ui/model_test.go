@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+func TestHumanizeAge(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{5 * time.Second, "now"},
+		{59*time.Minute + 30*time.Second, "59m"},
+		{23*time.Hour + 30*time.Minute, "23h"},
+		{6*day + 12*time.Hour, "6d"},
+		{51*7*day + 3*day, "51w"},
+		{400 * day, "1y"},
+	}
+	for _, tt := range tests {
+		if got := humanizeAge(time.Now().Add(-tt.ago).Unix()); got != tt.want {
+			t.Errorf("humanizeAge(%v ago) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+	if got := humanizeAge(0); got != "" {
+		t.Errorf("humanizeAge(0) = %q, want empty", got)
+	}
+}
+
+func TestTruncateAndPadWideRunes(t *testing.T) {
+	tests := []struct {
+		s        string
+		width    int
+		truncate string
+		pad      string
+	}{
+		{"repo", 6, "repo", "repo  "},
+		{"gitpulse", 5, "gitp…", "gitpulse"},
+		{"日本語", 6, "日本語", "日本語"},
+		{"日本語", 5, "日本…", "日本語"},
+		{"日本語", 8, "日本語", "日本語  "},
+		{"🚀go", 4, "🚀go", "🚀go"},
+		{"🚀🚀go", 3, "🚀…", "🚀🚀go"},
+		{"🚀", 3, "🚀", "🚀 "},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.width); got != tt.truncate {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.truncate)
+		}
+		if got := pad(tt.s, tt.width); got != tt.pad {
+			t.Errorf("pad(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.pad)
+		}
+	}
+}
+
+func TestOverlappingRefreshesKeepRepoRefreshing(t *testing.T) {
+	var m tea.Model = Model{
+		refreshing:  map[string]int{"/gone": 2},
+		userRefresh: map[string]bool{"/gone": true},
+	}
+	msg := statusUpdatedMsg{repoRef: repoRef{path: "/gone"}, status: &git.RepoStatus{Path: "/gone"}}
+
+	m, _ = m.(Model).update(msg)
+	if got := m.(Model); got.refreshing["/gone"] != 1 || !got.userRefresh["/gone"] {
+		t.Fatalf("first of two refreshes ended refreshing: %v %v", got.refreshing, got.userRefresh)
+	}
+	m, _ = m.(Model).update(msg)
+	if got := m.(Model); len(got.refreshing) != 0 || len(got.userRefresh) != 0 {
+		t.Errorf("refreshing after all refreshes are in: %v %v", got.refreshing, got.userRefresh)
+	}
+}
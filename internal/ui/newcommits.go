@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// newCommitsWindow is how long a fetch's new upstream commits stay badged
+const newCommitsWindow = 5 * time.Minute
+
+// newCommits is how many commits a fetch added to a repo's behind count
+type newCommits struct {
+	count int
+	at    time.Time
+}
+
+// noteBehind remembers how far behind a repo is before fetching it
+func (m *Model) noteBehind(index int) {
+	if m.behindBefore == nil {
+		m.behindBefore = make(map[string]int)
+	}
+	m.behindBefore[m.repos[index].Path] = m.statuses[index].Behind
+}
+
+// checkNewCommits badges a repo whose behind count grew since noteBehind, once
+// the refresh after its fetch is in
+func (m *Model) checkNewCommits(index int) {
+	path := m.repos[index].Path
+	before, ok := m.behindBefore[path]
+	status := m.statuses[index]
+	if !ok || status.Fetching {
+		return
+	}
+	delete(m.behindBefore, path)
+	if status.Behind <= before {
+		return
+	}
+	if m.newCommits == nil {
+		m.newCommits = make(map[string]newCommits)
+	}
+	m.newCommits[path] = newCommits{count: status.Behind - before, at: time.Now()}
+}
+
+// newCommitsBadge returns e.g. "↓ +5 new" for a repo that recently fetched
+// new commits it hasn't pulled yet, or "" otherwise
+func (m Model) newCommitsBadge(index int) string {
+	n, ok := m.newCommits[m.repos[index].Path]
+	if !ok || time.Since(n.at) > newCommitsWindow || m.statuses[index].Behind == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s +%d new", m.glyphs.Behind, n.count)
+}
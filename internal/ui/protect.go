@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// isProtected reports whether a repo is on one of the protected_branches,
+// which are only pushed after a confirmation
+func (m Model) isProtected(index int) bool {
+	s := m.statuses[index]
+	return !s.Detached && slices.Contains(m.cfg.ProtectedBranches(), s.Branch)
+}
+
+// beginPush marks a repo as pushing, reporting false when a push is already
+// running
+func (m *Model) beginPush(index int) bool {
+	s := m.statuses[index]
+	if s.Pushing {
+		return false
+	}
+	s.Pushing = true
+	s.LastMessage = ""
+	return true
+}
+
+// confirmProtectedPush asks before pushing a protected branch to
+// remote/branch
+func (m *Model) confirmProtectedPush(index int, remote, branch string) tea.Cmd {
+	status := m.statuses[index]
+	cmd := m.showText(index, "Push protected "+status.Branch, func() ([]string, error) {
+		return []string{
+			fmt.Sprintf("%s is a protected branch.", status.Branch),
+			fmt.Sprintf("Push %d commits directly to %s/%s?", status.Unpushed(), remote, branch),
+		}, nil
+	})
+	m.textConfirmLabel = "push"
+	m.textConfirm = func(m *Model) tea.Cmd {
+		if !m.beginPush(index) {
+			return nil
+		}
+		return m.pushTo(index, remote, branch)
+	}
+	return cmd
+}
+
+// confirmProtectedPushAll lists the repos push all left out for being on a
+// protected branch, pushing them on confirmation
+func (m *Model) confirmProtectedPushAll(targets []int) tea.Cmd {
+	lines := []string{"These repos are on a protected branch:", ""}
+	for _, idx := range targets {
+		s := m.statuses[idx]
+		lines = append(lines, fmt.Sprintf("%s  %s (%s%d)", m.repos[idx].Name, s.Branch, m.glyphs.Ahead, s.Unpushed()))
+	}
+	cmd := m.showText(targets[0], fmt.Sprintf("Push %d protected branches", len(targets)), func() ([]string, error) {
+		return lines, nil
+	})
+	m.textConfirmLabel = "push them too"
+	m.textConfirm = func(m *Model) tea.Cmd {
+		cmds := make([]tea.Cmd, 0, len(targets))
+		for _, idx := range targets {
+			if m.beginPush(idx) {
+				cmds = append(cmds, m.pushRepo(idx))
+			}
+		}
+		return tea.Batch(cmds...)
+	}
+	return cmd
+}
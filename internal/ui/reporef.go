@@ -0,0 +1,29 @@
+package ui
+
+// repoRef identifies the repo an operation's result belongs to. The repo
+// list may be reloaded or reordered while the operation runs, so the index it
+// started with is only trusted after resolve has checked it against the path.
+type repoRef struct {
+	index int
+	path  string
+}
+
+// ref returns a reference to the repo at index
+func (m *Model) ref(index int) repoRef {
+	return repoRef{index: index, path: m.repos[index].Path}
+}
+
+// resolve points ref at the repo's current index, reporting false when the
+// repo is no longer listed
+func (m *Model) resolve(ref *repoRef) bool {
+	if ref.index < len(m.repos) && m.repos[ref.index].Path == ref.path {
+		return true
+	}
+	for i, repo := range m.repos {
+		if repo.Path == ref.path {
+			ref.index = i
+			return true
+		}
+	}
+	return false
+}
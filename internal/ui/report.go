@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// statusTable renders all repos as a GitHub-flavored markdown table, e.g. for
+// pasting into standup notes
+func statusTable(statuses []*repoStatus) string {
+	var b strings.Builder
+	b.WriteString("| Repo | Branch | Ahead | Behind | Dirty | Status |\n")
+	b.WriteString("|------|--------|------:|-------:|:-----:|--------|\n")
+	for _, s := range statuses {
+		dirty := ""
+		if s.Dirty {
+			dirty = "yes"
+		}
+		ahead, behind := "", ""
+		if s.HasUpstream && s.Error == nil {
+			ahead, behind = strconv.Itoa(s.Unpushed()), strconv.Itoa(s.Behind)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			tableCell(s.Name), tableCell(s.HeadLabel()), ahead, behind, dirty, tableCell(reportStatus(s)))
+	}
+	return b.String()
+}
+
+// reportStatus describes a repo's state in a word or two
+func reportStatus(s *repoStatus) string {
+	switch {
+	case s.Error != nil:
+		return "error: " + s.Error.Error()
+	case s.RemoteChanged:
+		return "new commits"
+	case s.RemoteOnly:
+		return "remote"
+	case !s.HasUpstream:
+		return "no upstream"
+	case s.IsDiverged():
+		return "diverged"
+	case s.NeedsPull():
+		return "behind"
+	case s.NeedsPush():
+		return "ahead"
+	}
+	return "synced"
+}
+
+// tableCell escapes text for a markdown table cell
+func tableCell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
+
+// copyStatusTable copies the markdown status table of all repos to the
+// clipboard
+func (m *Model) copyStatusTable() {
+	if err := clipboard.WriteAll(statusTable(m.statuses)); err != nil {
+		m.notice = fmt.Sprintf("copy failed: %v", err)
+		return
+	}
+	m.notice = fmt.Sprintf("copied status table of %d repos", len(m.statuses))
+}
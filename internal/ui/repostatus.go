@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// repoStatus is what a row shows: the status git.GetStatus computed, plus
+// the operation state the UI keeps for the repo
+type repoStatus struct {
+	git.RepoStatus
+	opState
+}
+
+// opState is the state of operations on a repo. Refreshes know nothing
+// about it, so it is kept when a fresh status comes in.
+type opState struct {
+	Fetching    bool
+	Rebasing    bool
+	Pushing     bool
+	Maintaining bool
+	LastMessage string
+	History     []string  // Recent operation results, oldest first
+	PulledAt    time.Time // When a pull last moved HEAD; zero if never
+	PulledFrom  string    // Hash HEAD pointed at before that pull
+}
+
+// update replaces the status with a freshly computed one. A refresh that
+// lands while an operation is still running, or after it completed, can't
+// clobber its flags or result.
+func (s *repoStatus) update(fresh *git.RepoStatus) {
+	s.RepoStatus = *fresh
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+func TestUpdateKeepsOperationState(t *testing.T) {
+	pulled := time.Now().Add(-time.Minute)
+	s := &repoStatus{
+		RepoStatus: git.RepoStatus{Branch: "main", Behind: 3, Dirty: true},
+		opState: opState{
+			Fetching:    true,
+			Rebasing:    true,
+			Pushing:     true,
+			Maintaining: true,
+			LastMessage: "synced",
+			History:     []string{"fetched", "synced"},
+			PulledAt:    pulled,
+			PulledFrom:  "abc123",
+		},
+	}
+
+	s.update(&git.RepoStatus{Branch: "feature", Ahead: 2, Error: errors.New("boom")})
+
+	if s.Branch != "feature" || s.Ahead != 2 || s.Behind != 0 || s.Dirty || s.Error == nil {
+		t.Errorf("fresh fields not taken: %+v", s.RepoStatus)
+	}
+	if !s.Fetching || !s.Rebasing || !s.Pushing || !s.Maintaining {
+		t.Errorf("operation flags lost: %+v", s.opState)
+	}
+	if s.LastMessage != "synced" || !slices.Equal(s.History, []string{"fetched", "synced"}) {
+		t.Errorf("operation results lost: %q %q", s.LastMessage, s.History)
+	}
+	if !s.PulledAt.Equal(pulled) || s.PulledFrom != "abc123" {
+		t.Errorf("pull undo state lost: %v %q", s.PulledAt, s.PulledFrom)
+	}
+}
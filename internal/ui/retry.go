@@ -0,0 +1,67 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// retryOp is an operation that can be dispatched again on a repo, setting
+// its progress state the way the original key did
+type retryOp struct {
+	name   string
+	run    func(m *Model, index int) tea.Cmd
+	failed bool // Set once the operation completed with an error
+}
+
+// remember records op as the last operation dispatched on a repo
+func (m *Model) remember(index int, name string, run func(m *Model, index int) tea.Cmd) {
+	if m.lastOps == nil {
+		m.lastOps = make(map[string]retryOp)
+	}
+	m.lastOps[m.repos[index].Path] = retryOp{name: name, run: run}
+}
+
+// settle records how the last operation dispatched on a repo ended: a failed
+// one is kept for retryLast, one that succeeded is forgotten
+func (m *Model) settle(index int, err error) {
+	path := m.repos[index].Path
+	op, ok := m.lastOps[path]
+	if !ok {
+		return
+	}
+	if err == nil {
+		delete(m.lastOps, path)
+		return
+	}
+	op.failed = true
+	m.lastOps[path] = op
+}
+
+// retryLast dispatches the last operation on a repo again, if it failed
+func (m *Model) retryLast(index int) tea.Cmd {
+	op, ok := m.lastOps[m.repos[index].Path]
+	if !ok || !op.failed {
+		m.notice = "no failed operation to retry"
+		return nil
+	}
+	m.notice = "retrying " + op.name
+	return op.run(m, index)
+}
+
+// retryFetch and retryPush re-dispatch an operation unless one is already
+// running on the repo
+func retryFetch(m *Model, index int) tea.Cmd {
+	s := m.statuses[index]
+	if s.Fetching || s.Rebasing {
+		return nil
+	}
+	s.Fetching = true
+	s.LastMessage = ""
+	return m.fetchRepo(index)
+}
+
+func retryPush(m *Model, index int) tea.Cmd {
+	if !m.beginPush(index) {
+		return nil
+	}
+	return m.pushRepo(index)
+}
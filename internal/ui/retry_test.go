@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+func TestRetryLastOnlyAfterFailure(t *testing.T) {
+	m := &Model{
+		repos:    []config.RepoConfig{{Name: "repo", Path: "/tmp/repo"}},
+		statuses: []*repoStatus{{RepoStatus: git.RepoStatus{Name: "repo"}}},
+	}
+	runs := 0
+	run := func(m *Model, index int) tea.Cmd {
+		runs++
+		return nil
+	}
+
+	m.remember(0, "fetch", run)
+	m.retryLast(0)
+	if runs != 0 {
+		t.Fatalf("retried an operation still in flight")
+	}
+
+	m.settle(0, errors.New("boom"))
+	m.retryLast(0)
+	if runs != 1 {
+		t.Fatalf("failed operation not retried")
+	}
+
+	m.settle(0, nil)
+	m.retryLast(0)
+	if runs != 1 {
+		t.Errorf("retried an operation that succeeded")
+	}
+	if _, ok := m.lastOps["/tmp/repo"]; ok {
+		t.Errorf("succeeded operation still remembered")
+	}
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+)
+
+// markReviewed stamps a repo as reviewed now, persisting it to the state file
+func (m *Model) markReviewed(index int) {
+	status := m.statuses[index]
+	m.state.Reviewed[m.repos[index].Path] = time.Now()
+	if err := config.SaveState(m.state); err != nil {
+		setMessage(status, fmt.Sprintf("save state failed: %v", err))
+		return
+	}
+	m.notice = "marked " + status.Name + " as reviewed"
+}
+
+// reviewedLabel returns how long ago a repo was last marked reviewed, e.g.
+// "reviewed 3d ago", or "" if it never was
+func (m Model) reviewedLabel(index int) string {
+	at, ok := m.state.Reviewed[m.repos[index].Path]
+	if !ok {
+		return ""
+	}
+	age := humanizeAge(at.Unix())
+	if age == "now" {
+		return "reviewed now"
+	}
+	return "reviewed " + age + " ago"
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// statusCommandTimeout bounds how long status_command may run for a repo, so
+// a hanging script doesn't hold up refreshes
+const statusCommandTimeout = 5 * time.Second
+
+// statusCommandWidth caps the width of the status_command column
+const statusCommandWidth = 16
+
+// runStatusCommand runs the status_command in a repo, passing it the repo
+// path, and returns the first line it prints
+func runStatusCommand(args []string, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], append(args[1:], path)...)
+	cmd.Dir = path
+	// Children the command started may keep stdout open after it is killed
+	cmd.WaitDelay = time.Second
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("timed out after %s", statusCommandTimeout)
+		}
+		return "", err
+	}
+	line, _, _ := strings.Cut(stdout.String(), "\n")
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+type tagCreatedMsg struct {
+	repoRef
+	name string
+	err  error
+}
+
+type tagNameCheckedMsg struct {
+	repoRef
+	name string
+	err  error
+}
+
+type tagPushedMsg struct {
+	repoRef
+	name string
+	err  error
+}
+
+// showTagModal prompts for the name, then the optional message, of a tag on
+// HEAD of a repo
+func (m *Model) showTagModal(index int) tea.Cmd {
+	status := m.statuses[index]
+	if status.RemoteOnly || status.Error != nil || status.HeadHash == "" {
+		return nil
+	}
+	m.modalType = ModalTag
+	m.modalRepoIndex = index
+	m.tagName = ""
+	m.tagErr = nil
+	m.textInput.Placeholder = "tag name, e.g. v1.2.3"
+	m.textInput.Reset()
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+// handleTagKey handles keys in the tag prompt
+func (m Model) handleTagKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.modalType = ModalNone
+		m.textInput.Blur()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.textInput.Value())
+		// First the name, checked right away so typos can be fixed in place
+		if m.tagName == "" {
+			if value == "" {
+				return m, nil
+			}
+			return m, m.checkTagName(m.modalRepoIndex, value)
+		}
+		m.modalType = ModalNone
+		m.textInput.Blur()
+		return m, m.createTag(m.modalRepoIndex, m.tagName, value)
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// checkTagName validates a tag name off the update loop, since it runs git
+func (m *Model) checkTagName(index int, name string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		return tagNameCheckedMsg{repoRef: ref, name: name, err: git.ValidateTagName(path, name)}
+	}
+}
+
+// tagNameChecked moves the tag prompt on to the message once the name is
+// valid, unless the prompt was closed or the name edited in the meantime
+func (m *Model) tagNameChecked(msg tagNameCheckedMsg) {
+	if m.modalType != ModalTag || m.modalRepoIndex != msg.index || m.tagName != "" ||
+		strings.TrimSpace(m.textInput.Value()) != msg.name {
+		return
+	}
+	if msg.err != nil {
+		m.tagErr = msg.err
+		return
+	}
+	m.tagName = msg.name
+	m.tagErr = nil
+	m.textInput.Placeholder = "message (empty for a lightweight tag)"
+	m.textInput.Reset()
+}
+
+func (m *Model) createTag(index int, name, message string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		err := git.CreateTag(path, name, message)
+		return tagCreatedMsg{repoRef: ref, name: name, err: err}
+	}
+}
+
+func (m *Model) pushTag(index int, remote, name string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		err := git.PushTag(path, remote, name)
+		return tagPushedMsg{repoRef: ref, name: name, err: err}
+	}
+}
+
+// tagCreated records a created tag and offers to push it, unless another
+// modal was opened in the meantime
+func (m *Model) tagCreated(msg tagCreatedMsg) tea.Cmd {
+	m.logger.Log(m.repos[msg.index].Name, "tag", msg.err)
+	if msg.err != nil {
+		setMessage(m.statuses[msg.index], fmt.Sprintf("tag failed: %v", msg.err))
+		return nil
+	}
+	setMessage(m.statuses[msg.index], "tagged "+msg.name)
+	if m.modalType != ModalNone {
+		return nil
+	}
+
+	remote := "origin"
+	if r, _, ok := strings.Cut(m.statuses[msg.index].Upstream, "/"); ok {
+		remote = r
+	}
+	cmd := m.showText(msg.index, "Tagged "+msg.name, func() ([]string, error) {
+		return []string{fmt.Sprintf("Push %s to %s?", msg.name, remote)}, nil
+	})
+	m.textConfirmLabel = "push tag"
+	m.textConfirm = func(m *Model) tea.Cmd {
+		return m.pushTag(msg.index, remote, msg.name)
+	}
+	return cmd
+}
+
+// tagPushed records the outcome of pushing a tag
+func (m *Model) tagPushed(msg tagPushedMsg) {
+	m.logger.Log(m.repos[msg.index].Name, "push tag", msg.err)
+	if msg.err != nil {
+		setMessage(m.statuses[msg.index], fmt.Sprintf("tag push failed: %v", msg.err))
+		return
+	}
+	setMessage(m.statuses[msg.index], "pushed tag "+msg.name)
+}
+
+// renderTag returns the title and body of the tag prompt
+func (m Model) renderTag() (string, string) {
+	t := m.theme
+	status := m.statuses[m.modalRepoIndex]
+	note := fmt.Sprintf("Tags %s: %s", status.HeadLabel(), status.CommitSubject)
+	if m.tagName != "" {
+		note = fmt.Sprintf("Tag %s: enter a message for an annotated tag", m.tagName)
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(t.Dim).Render(note),
+		"",
+		m.textInput.View(),
+	}
+	if m.tagErr != nil {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(t.Error).Render(m.tagErr.Error()))
+	}
+	return "Tag " + status.Name, strings.Join(lines, "\n")
+}
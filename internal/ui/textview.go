@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// logLimit is the number of commits shown in the log view
+const logLimit = 100
+
+// textLoadedMsg carries the content of the scrollable text modal
+type textLoadedMsg struct {
+	repoRef
+	lines []string
+	err   error
+}
+
+// showText opens a scrollable read-only modal whose lines are produced by load
+func (m *Model) showText(index int, title string, load func() ([]string, error)) tea.Cmd {
+	ref := m.ref(index)
+	m.modalType = ModalText
+	m.modalRepoIndex = index
+	m.modalScroll = 0
+	m.textTitle = title
+	m.textLines = nil
+	m.textErr = nil
+	m.textConfirm = nil
+	m.textConfirmLabel = ""
+	m.textParent = ModalNone
+	return func() tea.Msg {
+		lines, err := load()
+		return textLoadedMsg{repoRef: ref, lines: lines, err: err}
+	}
+}
+
+// renderText returns the body of the text modal, wrapped to width
+func (m Model) renderText(wrapWidth int) []string {
+	t := m.theme
+	switch {
+	case m.textErr != nil:
+		return []string{lipgloss.NewStyle().Foreground(t.Error).Width(wrapWidth).Render(m.textErr.Error())}
+	case m.textLines == nil:
+		return []string{lipgloss.NewStyle().Foreground(t.Dim).Render(m.spinner.View() + " loading…")}
+	}
+
+	wrap := lipgloss.NewStyle().Width(wrapWidth)
+	var lines []string
+	for _, line := range m.textLines {
+		lines = append(lines, strings.Split(wrap.Render(line), "\n")...)
+	}
+	return lines
+}
+
+// showLog opens the recent commit log of a repo
+func (m *Model) showLog(index int) tea.Cmd {
+	path := m.repos[index].Path
+	t := m.theme
+	return m.showText(index, fmt.Sprintf("Log of %s", m.repos[index].Name), func() ([]string, error) {
+		commits, err := git.RecentCommits(path, logLimit)
+		if err != nil {
+			return nil, err
+		}
+		return commitLines(t, commits), nil
+	})
+}
+
+// showIncoming previews commits that a pull would bring in, offering to sync
+func (m *Model) showIncoming(index int) tea.Cmd {
+	status := m.statuses[index]
+	if !status.HasUpstream || status.RemoteOnly || status.Error != nil {
+		return nil
+	}
+	path := m.repos[index].Path
+	t := m.theme
+	cmd := m.showText(index, fmt.Sprintf("Incoming from %s", status.Upstream), func() ([]string, error) {
+		commits, err := git.IncomingCommits(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 {
+			return []string{lipgloss.NewStyle().Foreground(t.Synced).Render("Up to date")}, nil
+		}
+		return commitLines(t, commits), nil
+	})
+	if status.Behind > 0 {
+		m.textConfirmLabel = "sync"
+		m.textConfirm = func(m *Model) tea.Cmd {
+			return m.syncRepo(index)
+		}
+	}
+	return cmd
+}
+
+// showOutgoing previews commits that a push would send, offering to push
+func (m *Model) showOutgoing(index int) tea.Cmd {
+	status := m.statuses[index]
+	if status.RemoteOnly || status.Error != nil {
+		return nil
+	}
+	if !status.HasUpstream {
+		m.notice = "no upstream to push to"
+		return nil
+	}
+	path := m.repos[index].Path
+	t := m.theme
+	cmd := m.showText(index, fmt.Sprintf("Outgoing to %s", status.Upstream), func() ([]string, error) {
+		commits, err := git.OutgoingCommits(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 {
+			return []string{lipgloss.NewStyle().Foreground(t.Synced).Render("Nothing to push")}, nil
+		}
+		return commitLines(t, commits), nil
+	})
+	if status.Ahead > 0 {
+		m.textConfirmLabel = "push"
+		if m.isProtected(index) {
+			m.textConfirmLabel = "push to protected " + status.Branch
+		}
+		m.textConfirm = func(m *Model) tea.Cmd {
+			if !m.beginPush(index) {
+				return nil
+			}
+			return m.pushRepo(index)
+		}
+	}
+	return cmd
+}
+
+// showStatus shows the full `git status` output of a repo
+func (m *Model) showStatus(index int) tea.Cmd {
+	status := m.statuses[index]
+	if status.RemoteOnly {
+		return nil
+	}
+	path := m.repos[index].Path
+	return m.showText(index, fmt.Sprintf("git status in %s", status.Name), func() ([]string, error) {
+		output, err := git.StatusVerbose(path)
+		if err != nil {
+			return nil, err
+		}
+		return strings.Split(strings.TrimRight(output, "\n"), "\n"), nil
+	})
+}
+
+// showUpstreamDiff fetches and shows the file-level stat of incoming changes
+func (m *Model) showUpstreamDiff(index int) tea.Cmd {
+	status := m.statuses[index]
+	if !status.HasUpstream || status.RemoteOnly || status.Error != nil {
+		return nil
+	}
+	path := m.repos[index].Path
+	prune := m.cfg.FetchPrunes()
+	tags := m.repos[index].FetchTags
+	t := m.theme
+	return m.showText(index, fmt.Sprintf("Changes in %s", status.Upstream), func() ([]string, error) {
+		if err := git.Fetch(path, prune, tags); err != nil {
+			return nil, err
+		}
+		stat, err := git.DiffStatUpstream(path)
+		if err != nil {
+			return nil, err
+		}
+		stat = strings.TrimRight(stat, "\n")
+		if stat == "" {
+			return []string{lipgloss.NewStyle().Foreground(t.Synced).Render("Up to date")}, nil
+		}
+		return strings.Split(stat, "\n"), nil
+	})
+}
+
+// showFileDiff opens the uncommitted diff of one file from the detail view,
+// returning to it when closed
+func (m *Model) showFileDiff(index int, file string) tea.Cmd {
+	path := m.repos[index].Path
+	t := m.theme
+	cmd := m.showText(index, "Diff of "+file, func() ([]string, error) {
+		diff, err := git.FileDiff(path, file)
+		if err != nil {
+			return nil, err
+		}
+		return diffLines(t, diff), nil
+	})
+	m.textParent = ModalDetail
+	return cmd
+}
+
+// diffLines colors a diff: additions, removals, hunk headers and file headers
+func diffLines(t Theme, diff string) []string {
+	diff = strings.TrimRight(diff, "\n")
+	if diff == "" {
+		return []string{lipgloss.NewStyle().Foreground(t.Dim).Render("No changes")}
+	}
+	var lines []string
+	for _, line := range strings.Split(diff, "\n") {
+		style := lipgloss.NewStyle().Foreground(t.RepoName)
+		switch {
+		case strings.HasPrefix(line, "Binary files "):
+			return []string{lipgloss.NewStyle().Foreground(t.Dim).Render("binary file differs")}
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+			strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "),
+			strings.HasPrefix(line, "new file"), strings.HasPrefix(line, "deleted file"):
+			style = lipgloss.NewStyle().Foreground(t.Dim)
+		case strings.HasPrefix(line, "@@"):
+			style = lipgloss.NewStyle().Foreground(t.HelpKey)
+		case strings.HasPrefix(line, "+"):
+			style = lipgloss.NewStyle().Foreground(t.Synced)
+		case strings.HasPrefix(line, "-"):
+			style = lipgloss.NewStyle().Foreground(t.Behind)
+		}
+		lines = append(lines, style.Render(line))
+	}
+	return lines
+}
+
+// commitLines renders one line per commit: hash, subject, author and age
+func commitLines(t Theme, commits []git.Commit) []string {
+	lines := make([]string, 0, len(commits))
+	for _, c := range commits {
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Branch).Render(c.Hash)+" "+
+			lipgloss.NewStyle().Foreground(t.RepoName).Render(c.Subject)+" "+
+			lipgloss.NewStyle().Foreground(t.Dim).Render(fmt.Sprintf("(%s, %s)", c.Author, c.Age)))
+	}
+	return lines
+}
@@ -1,6 +1,9 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/config"
+)
 
 type Theme struct {
 	Name        string
@@ -168,6 +171,19 @@ func GetTheme(name string) Theme {
 	return Themes[DefaultTheme]
 }
 
+// configTheme returns the configured theme with the ahead/behind color
+// overrides from the config applied on top
+func configTheme(cfg *config.Config) Theme {
+	theme := GetTheme(cfg.Theme)
+	if cfg.AheadColor != "" {
+		theme.Ahead = lipgloss.Color(cfg.AheadColor)
+	}
+	if cfg.BehindColor != "" {
+		theme.Behind = lipgloss.Color(cfg.BehindColor)
+	}
+	return theme
+}
+
 func ThemeNames() []string {
 	names := make([]string, 0, len(Themes))
 	for name := range Themes {
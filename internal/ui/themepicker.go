@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/d12frosted/gitpulse/internal/config"
+)
+
+// showThemePicker opens the theme picker on the current theme
+func (m *Model) showThemePicker() {
+	m.modalType = ModalTheme
+	m.themeNames = ThemeNames()
+	slices.Sort(m.themeNames)
+	m.modalCursor = max(slices.Index(m.themeNames, m.theme.Name), 0)
+}
+
+// previewTheme applies the highlighted theme, keeping the color overrides
+func (m *Model) previewTheme() {
+	preview := *m.cfg
+	preview.Theme = m.themeNames[m.modalCursor]
+	m.theme = configTheme(&preview)
+	m.spinner.Style = lipgloss.NewStyle().Foreground(m.theme.Spinner)
+}
+
+// handleThemeKey handles keys in the theme picker. Moving previews the
+// highlighted theme, enter saves it and esc reverts to the configured one.
+func (m Model) handleThemeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "T":
+		m.modalType = ModalNone
+		m.theme = configTheme(m.cfg)
+		m.spinner.Style = lipgloss.NewStyle().Foreground(m.theme.Spinner)
+	case "up", "k":
+		if m.modalCursor > 0 {
+			m.modalCursor--
+			m.previewTheme()
+		}
+	case "down", "j":
+		if m.modalCursor < len(m.themeNames)-1 {
+			m.modalCursor++
+			m.previewTheme()
+		}
+	case "enter", " ":
+		m.modalType = ModalNone
+		m.cfg.Theme = m.themeNames[m.modalCursor]
+		m.previewTheme()
+		if m.adhoc {
+			m.notice = "theme set to " + m.cfg.Theme + " for this session"
+		} else if err := config.Save(m.cfg); err != nil {
+			m.notice = fmt.Sprintf("save theme failed: %v", err)
+		} else {
+			m.notice = "theme set to " + m.cfg.Theme
+		}
+	}
+	return m, nil
+}
+
+// renderThemePicker returns the body of the theme picker: the theme names
+// and a few sample rows, all in the highlighted theme's colors
+func (m Model) renderThemePicker() string {
+	t, g := m.theme, m.glyphs
+	var lines []string
+	for i, name := range m.themeNames {
+		cursor := cursorGlyph(m.glyphs, i == m.modalCursor)
+		style := lipgloss.NewStyle().Foreground(t.RepoName)
+		if i == m.modalCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+		}
+		lines = append(lines, cursor+style.Render(name))
+	}
+
+	sample := func(name, branch string, status string) string {
+		return lipgloss.NewStyle().Foreground(t.RepoName).Render(pad(name, 8)) + " " +
+			lipgloss.NewStyle().Foreground(t.Branch).Render(pad(branch, 8)) + " " + status
+	}
+	lines = append(lines, "",
+		sample("api", "main", lipgloss.NewStyle().Foreground(t.Synced).Render(g.Synced+" synced")),
+		sample("web", "feature", lipgloss.NewStyle().Bold(true).Foreground(t.Ahead).Render(g.Ahead+"2")+" "+
+			lipgloss.NewStyle().Bold(true).Foreground(t.Behind).Render(g.Behind+"5")),
+		sample("docs", "main", lipgloss.NewStyle().Foreground(t.NoRemote).Render(g.NoUpstream+" no upstream")),
+		sample("legacy", "master", lipgloss.NewStyle().Foreground(t.Error).Render(g.Error+" error")),
+		lipgloss.NewStyle().Foreground(t.Dim).Render("[12/03/26 10:15:00] synced"),
+	)
+	return strings.Join(lines, "\n")
+}
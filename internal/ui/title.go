@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// terminalTitle summarizes what needs attention, e.g. gitpulse: 3 behind
+func (m Model) terminalTitle() string {
+	if labels := m.countLabels(); len(labels) > 0 {
+		return "gitpulse: " + strings.Join(labels, ", ")
+	}
+	return "gitpulse"
+}
+
+// updateTitle sets the terminal title when set_terminal_title is on and the
+// title changed since it was last set
+func (m *Model) updateTitle() tea.Cmd {
+	if !m.cfg.TerminalTitleEnabled() {
+		return nil
+	}
+	title := m.terminalTitle()
+	if title == m.title {
+		return nil
+	}
+	m.title = title
+	return tea.SetWindowTitle(title)
+}
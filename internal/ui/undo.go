@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// undoWindow is how long after a pull it can be undone. Later on the repo
+// may well have moved on, so resetting it back is less likely what's wanted.
+const undoWindow = 10 * time.Minute
+
+type undoCompleteMsg struct {
+	repoRef
+	err error
+}
+
+// canUndoPull reports whether the repo was pulled recently enough to undo
+func canUndoPull(s *repoStatus) bool {
+	return !s.PulledAt.IsZero() && s.PulledFrom != "" && time.Since(s.PulledAt) < undoWindow &&
+		!s.Fetching && !s.Rebasing
+}
+
+// confirmUndoPull shows where an undo would take the repo and resets it on
+// confirmation
+func (m *Model) confirmUndoPull(index int) tea.Cmd {
+	status := m.statuses[index]
+	if !canUndoPull(status) {
+		setMessage(status, "nothing to undo: no recent pull")
+		return nil
+	}
+	path := m.repos[index].Path
+	from := status.PulledFrom
+	ago := time.Since(status.PulledAt).Round(time.Second)
+	cmd := m.showText(index, "Undo pull in "+status.Name, func() ([]string, error) {
+		orig, err := git.CommitSummary(path, from)
+		if err != nil {
+			return nil, fmt.Errorf("commit before the pull is gone: %v", err)
+		}
+		return []string{
+			fmt.Sprintf("Pulled %s ago. Reset HEAD back to:", ago),
+			"",
+			"  " + orig,
+			"",
+			"This is a hard reset (git reset --hard).",
+			"Pulled commits and all uncommitted changes, including",
+			"autostashed ones, will be lost.",
+		}, nil
+	})
+	m.textConfirmLabel = "hard reset"
+	m.textConfirm = func(m *Model) tea.Cmd {
+		return m.undoPull(index, from)
+	}
+	return cmd
+}
+
+func (m *Model) undoPull(index int, hash string) tea.Cmd {
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	return func() tea.Msg {
+		err := git.ResetHard(path, hash)
+		return undoCompleteMsg{repoRef: ref, err: err}
+	}
+}
+
+// undoComplete records the outcome of undoing a pull. Either way the pull
+// can't be undone twice.
+func (m *Model) undoComplete(msg undoCompleteMsg) tea.Cmd {
+	m.logger.Log(m.repos[msg.index].Name, "undo pull", msg.err)
+	status := m.statuses[msg.index]
+	if msg.err != nil {
+		setMessage(status, fmt.Sprintf("undo failed: %v", msg.err))
+	} else {
+		status.PulledAt, status.PulledFrom = time.Time{}, ""
+		setMessage(status, "undid pull")
+	}
+	return tea.Batch(m.refreshStatus(msg.index, m.repos[msg.index]), m.errorBell(msg.err))
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+type unshallowProgressMsg struct {
+	repoRef
+	line     string
+	progress <-chan string
+	done     <-chan error
+}
+
+type unshallowCompleteMsg struct {
+	repoRef
+	err error
+}
+
+// unshallowRepo fetches the full history of a shallow clone, showing git's
+// progress in place of the repo's last message
+func (m *Model) unshallowRepo(index int) tea.Cmd {
+	status := m.statuses[index]
+	if !status.Shallow || status.Fetching || status.Rebasing {
+		return nil
+	}
+	status.Fetching = true
+	status.LastMessage = ""
+
+	ref := m.ref(index)
+	path := m.repos[index].Path
+	m.remember(index, "unshallow", (*Model).unshallowRepo)
+	progress := make(chan string)
+	done := make(chan error, 1)
+	return func() tea.Msg {
+		go func() {
+			// Lines nobody is waiting for are dropped rather than blocking
+			// the fetch, e.g. while the UI is busy or after it quit. The
+			// next line redraws the progress anyway.
+			done <- git.Unshallow(path, func(line string) {
+				select {
+				case progress <- line:
+				default:
+				}
+			})
+			close(progress)
+		}()
+		return waitUnshallow(ref, progress, done)()
+	}
+}
+
+// waitUnshallow waits for the next progress line of an unshallow, or for it
+// to finish
+func waitUnshallow(ref repoRef, progress <-chan string, done <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-progress
+		if !ok {
+			return unshallowCompleteMsg{repoRef: ref, err: <-done}
+		}
+		return unshallowProgressMsg{repoRef: ref, line: line, progress: progress, done: done}
+	}
+}
+
+// unshallowProgress shows a progress line and waits for the next one.
+// Progress isn't recorded in the history, only the outcome is.
+func (m *Model) unshallowProgress(msg unshallowProgressMsg) tea.Cmd {
+	m.statuses[msg.index].LastMessage = msg.line
+	return waitUnshallow(msg.repoRef, msg.progress, msg.done)
+}
+
+// unshallowComplete records the outcome of an unshallow
+func (m *Model) unshallowComplete(msg unshallowCompleteMsg) tea.Cmd {
+	m.logger.Log(m.repos[msg.index].Name, "unshallow", msg.err)
+	m.settle(msg.index, msg.err)
+	status := m.statuses[msg.index]
+	status.Fetching = false
+	if msg.err != nil {
+		setMessage(status, fmt.Sprintf("unshallow failed: %v", msg.err))
+	} else {
+		setMessage(status, "fetched full history")
+	}
+	return tea.Batch(m.refreshStatus(msg.index, m.repos[msg.index]), m.errorBell(msg.err))
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long a repo's .git dir must stay quiet before it is
+// refreshed, so rebases and other multi-step operations refresh only once
+const watchDebounce = 500 * time.Millisecond
+
+// repoChangedMsg reports that the refs of the repo at path changed
+type repoChangedMsg struct {
+	watcher *repoWatcher
+	path    string
+}
+
+// repoWatcher watches the .git dirs of local repos and reports changed repos
+type repoWatcher struct {
+	fs      *fsnotify.Watcher
+	changed chan string
+	done    chan struct{} // Closed by close
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// newRepoWatcher starts watching repos. Repos whose .git dir can't be watched
+// are skipped; an error is only returned when no watcher can be created.
+func newRepoWatcher(repos []config.RepoConfig) (*repoWatcher, error) {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &repoWatcher{
+		fs:      fs,
+		changed: make(chan string),
+		done:    make(chan struct{}),
+		timers:  make(map[string]*time.Timer),
+	}
+	for _, repo := range repos {
+		if repo.Remote {
+			continue
+		}
+		gitDir := filepath.Join(repo.Path, ".git")
+		// HEAD, COMMIT_EDITMSG and friends live in .git; branch refs one level down
+		_ = fs.Add(gitDir)
+		_ = fs.Add(filepath.Join(gitDir, "refs", "heads"))
+	}
+	go w.run()
+	return w, nil
+}
+
+// run turns raw events into debounced per-repo notifications
+func (w *repoWatcher) run() {
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if ignoredGitFile(filepath.Base(event.Name)) {
+				continue
+			}
+			if repo := repoOf(event.Name); repo != "" {
+				w.debounce(repo)
+			}
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (w *repoWatcher) debounce(repo string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t, ok := w.timers[repo]; ok {
+		t.Reset(watchDebounce)
+		return
+	}
+	w.timers[repo] = time.AfterFunc(watchDebounce, func() {
+		w.mu.Lock()
+		delete(w.timers, repo)
+		w.mu.Unlock()
+		select {
+		case w.changed <- repo:
+		case <-w.done:
+		}
+	})
+}
+
+// wait returns a command that blocks until the next repo change. Once the
+// watcher is closed it returns nothing.
+func (w *repoWatcher) wait() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case path := <-w.changed:
+			return repoChangedMsg{watcher: w, path: path}
+		case <-w.done:
+			return nil
+		}
+	}
+}
+
+// close stops watching, e.g. when the config is reloaded with other repos
+func (w *repoWatcher) close() {
+	close(w.done)
+	_ = w.fs.Close()
+}
+
+// ignoredGitFile reports whether changes to a .git entry should not trigger
+// a refresh. The index is rewritten by our own `git status`, and lock files
+// only precede the rename that produces the real change.
+func ignoredGitFile(name string) bool {
+	return name == "index" || strings.HasSuffix(name, ".lock")
+}
+
+// repoOf returns the repo path that a watched file belongs to
+func repoOf(name string) string {
+	sep := string(filepath.Separator)
+	i := strings.LastIndex(name, sep+".git"+sep)
+	if i < 0 {
+		return ""
+	}
+	return name[:i]
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// expandWorktrees adds a row for each other worktree of repos configured
+// with worktrees = true. They go after all other repos, so rows keep lining up
+// with config entries for reordering.
+func expandWorktrees(repos []config.RepoConfig) []config.RepoConfig {
+	// git reports resolved paths, which may differ from the configured ones
+	key := func(path string) string {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			return resolved
+		}
+		return filepath.Clean(path)
+	}
+	seen := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		seen[key(repo.Path)] = true
+	}
+
+	var extra []config.RepoConfig
+	for _, repo := range repos {
+		if !repo.Worktrees || repo.Remote {
+			continue
+		}
+		worktrees, err := git.ListWorktrees(repo.Path)
+		if err != nil {
+			continue
+		}
+		for _, wt := range worktrees {
+			if wt.Bare || wt.Prunable || seen[key(wt.Path)] {
+				continue
+			}
+			seen[key(wt.Path)] = true
+			extra = append(extra, config.RepoConfig{
+				Path:          wt.Path,
+				Name:          repo.Name + "/" + filepath.Base(wt.Path),
+				Icon:          repo.Icon,
+				Color:         repo.Color,
+				ManualRefresh: repo.ManualRefresh,
+				FetchTags:     repo.FetchTags,
+				PullStrategy:  repo.PullStrategy,
+			})
+		}
+	}
+	return slices.Concat(repos, extra)
+}
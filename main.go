@@ -3,7 +3,9 @@ package main
 import (
 	"bufio"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,41 +13,137 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+	"github.com/d12frosted/gitpulse/internal/oplog"
 	"github.com/d12frosted/gitpulse/internal/ui"
 )
 
 func main() {
+	repoPath := flag.String("repo", "", "act on a single repo without the TUI or config, then exit")
+	fetch := flag.Bool("fetch", false, "with --repo: fetch the repo")
+	sync := flag.Bool("sync", false, "with --repo: fetch and pull --rebase the repo")
+	logFile := flag.String("log", "", "append operation results to this file (overrides log_file)")
+	editConfig := flag.Bool("edit-config", false, "open the config file in $EDITOR, then exit")
+	doctor := flag.Bool("doctor", false, "check git, the config and repos for problems, then exit")
+	stdin := flag.Bool("stdin", false, "monitor the repo paths read from stdin, one per line, instead of the configured ones")
+	noAltScreen := flag.Bool("no-alt-screen", false, "render in the main screen, leaving the final state in the scrollback")
+	flag.Parse()
+
+	if *doctor || flag.Arg(0) == "doctor" {
+		os.Exit(runDoctor())
+	}
+
+	if *editConfig {
+		fmt.Println(config.ConfigPath())
+		cmd := config.EditCommand()
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *repoPath != "" {
+		// No config here, but $GITPULSE_GIT still applies
+		git.SetBinary(new(config.Config).GitBinary())
+		if err := git.CheckBinary(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(runOneShot(*repoPath, *fetch, *sync))
+	}
+
 	cfg, err := config.Load()
-	if err != nil {
-		var notFound *config.ConfigNotFoundError
-		if errors.As(err, &notFound) {
-			handleMissingConfig()
+	var notFound *config.ConfigNotFoundError
+	switch {
+	case err == nil:
+	case errors.As(err, &notFound) && *stdin:
+		// Settings keep their defaults, the repos come from stdin
+		cfg = new(config.Config)
+	case errors.As(err, &notFound):
+		// Go straight on with a freshly created config
+		if cfg = handleMissingConfig(); cfg == nil {
 			return
 		}
+	default:
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if len(cfg.Repos) == 0 {
+	git.SetBinary(cfg.GitBinary())
+	if err := git.CheckBinary(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	repos := cfg.RepoConfigs()
+	if *stdin {
+		repos = readRepos(os.Stdin)
+		for i := range repos {
+			repos[i] = cfg.WithDefaults(repos[i])
+		}
+		if len(repos) == 0 {
+			fmt.Fprintln(os.Stderr, "No repositories on stdin.")
+			os.Exit(1)
+		}
+	}
+	if len(repos) == 0 {
 		fmt.Println("No repositories configured.")
 		fmt.Printf("Add repositories to %s\n", config.ConfigPath())
 		os.Exit(1)
 	}
 
-	repos := cfg.RepoConfigs()
+	// --log is for this run only, so it's kept out of cfg, which gets saved
+	logPath := cfg.LogPath()
+	if *logFile != "" {
+		// Relative to the working directory, unlike log_file in the config
+		logPath, _ = filepath.Abs(expandPath(*logFile))
+	}
+	var logger *oplog.Logger
+	if logPath != "" {
+		logger, err = oplog.Open(logPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer logger.Close()
+	}
 
-	p := tea.NewProgram(
-		ui.NewModel(repos, cfg.Theme),
-		tea.WithAltScreen(),
-	)
+	model := ui.NewModel(repos, cfg).WithLogger(logger)
+	var opts []tea.ProgramOption
+	if *stdin {
+		// Keys come from the terminal, stdin was used up by the repo list
+		model = model.WithAdHocRepos()
+		opts = append(opts, tea.WithInputTTY())
+	}
+	if *noAltScreen {
+		model = model.WithoutAltScreen()
+	} else {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(model, opts...)
+	refreshOnSignal(p)
 
-	if _, err := p.Run(); err != nil {
+	// Save the terminal title on the title stack (xterm and most others),
+	// so it's restored on exit
+	titled := cfg.TerminalTitleEnabled()
+	if titled {
+		fmt.Print("\x1b[22;0t")
+	}
+	_, err = p.Run()
+	if titled {
+		fmt.Print("\x1b[23;0t")
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func handleMissingConfig() {
+// handleMissingConfig offers to create a config interactively, returning it
+// when it lists at least one git repo to start with, nil otherwise
+func handleMissingConfig() *config.Config {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("205"))
@@ -80,7 +178,7 @@ func handleMissingConfig() {
 	if input != "" && input != "y" && input != "yes" {
 		fmt.Println()
 		fmt.Println("  No config created. Exiting.")
-		return
+		return nil
 	}
 
 	// Interactive config creation
@@ -88,7 +186,8 @@ func handleMissingConfig() {
 	fmt.Println("  Enter repository paths (one per line, empty line to finish):")
 	fmt.Println()
 
-	var repos []string
+	var repos []config.RepoEntry
+	valid := 0
 	for {
 		fmt.Print("  > ")
 		line, _ := reader.ReadString('\n')
@@ -108,15 +207,17 @@ func handleMissingConfig() {
 		gitDir := filepath.Join(expanded, ".git")
 		if _, err := os.Stat(gitDir); os.IsNotExist(err) {
 			fmt.Printf("    %s is not a git repository, adding anyway\n", dimStyle.Render(line))
+		} else {
+			valid++
 		}
 
-		repos = append(repos, line)
+		repos = append(repos, config.RepoEntry{Path: line})
 	}
 
 	if len(repos) == 0 {
 		fmt.Println()
 		fmt.Println("  No repositories added. Exiting.")
-		return
+		return nil
 	}
 
 	cfg := &config.Config{Repos: repos}
@@ -127,8 +228,49 @@ func handleMissingConfig() {
 
 	fmt.Println()
 	fmt.Printf("  Config saved to %s\n", pathStyle.Render(config.ConfigPath()))
-	fmt.Println()
-	fmt.Println("  Run gitpulse again to start monitoring your repos.")
+	if valid == 0 {
+		// Nothing to monitor yet, the TUI would only show errors
+		fmt.Println()
+		fmt.Println("  None of the paths is a git repository yet.")
+		fmt.Println("  Fix them in the config, then run gitpulse again.")
+		return nil
+	}
+	return cfg
+}
+
+// readRepos reads repo paths, one per line, as printed by e.g.
+// find ~/src -name .git -type d. Paths to .git directories stand for the repo
+// containing them; blank lines and repeated repos are skipped.
+func readRepos(r io.Reader) []config.RepoConfig {
+	var repos []config.RepoConfig
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		path := filepath.Clean(expandPath(line))
+		if filepath.Base(path) == ".git" {
+			path = filepath.Dir(path)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		link := path
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		if link == path {
+			link = ""
+		}
+		repos = append(repos, config.RepoConfig{Path: path, Link: link, Name: filepath.Base(path)})
+	}
+	return repos
 }
 
 func expandPath(path string) string {
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/d12frosted/gitpulse/internal/config"
+	"github.com/d12frosted/gitpulse/internal/git"
+)
+
+// runOneShot acts on a single repo without the TUI or config file and
+// returns the process exit code
+func runOneShot(path string, fetch, sync bool) int {
+	expanded := expandPath(path)
+	repo := config.RepoConfig{Path: expanded, Name: filepath.Base(expanded)}
+
+	status := git.GetStatus(repo.Path, repo.Name)
+	if status.Error != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", repo.Name, status.Error)
+		return 1
+	}
+
+	if fetch || sync {
+		if !status.HasUpstream {
+			fmt.Fprintf(os.Stderr, "%s: no upstream configured for %s\n", repo.Name, status.Branch)
+			return 1
+		}
+		if err := git.Fetch(repo.Path, true, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: fetch failed: %v\n", repo.Name, err)
+			return 1
+		}
+		if sync {
+			if err := git.Pull(repo.Path, "rebase"); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: pull failed: %v\n", repo.Name, err)
+				return 1
+			}
+		}
+		status = git.GetStatus(repo.Path, repo.Name)
+	}
+
+	fmt.Printf("%s: %s\n", repo.Name, describeStatus(status))
+	if status.Error != nil {
+		return 1
+	}
+	return 0
+}
+
+// describeStatus returns a plain-text summary of a repo status
+func describeStatus(s *git.RepoStatus) string {
+	if s.Error != nil {
+		return "error: " + s.Error.Error()
+	}
+
+	parts := []string{s.HeadLabel()}
+	switch {
+	case !s.HasUpstream:
+		parts = append(parts, "no upstream")
+	case s.IsSynced():
+		parts = append(parts, "synced")
+	default:
+		if n := s.Unpushed(); n > 0 {
+			parts = append(parts, fmt.Sprintf("↑%d", n))
+		}
+		if s.Behind > 0 {
+			parts = append(parts, fmt.Sprintf("↓%d", s.Behind))
+		}
+	}
+	if s.Dirty {
+		parts = append(parts, "dirty")
+	}
+	return strings.Join(parts, " ")
+}
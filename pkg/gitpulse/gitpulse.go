@@ -0,0 +1,128 @@
+// Package gitpulse exposes the repository status logic behind the gitpulse
+// TUI so it can be embedded in other tools.
+//
+// All operations shell out to the git executable and work on a repository
+// path. Nothing here depends on the gitpulse config file or the TUI.
+package gitpulse
+
+import "github.com/d12frosted/gitpulse/internal/git"
+
+// RepoStatus is a snapshot of a repository's branch, upstream and working tree state.
+// Fields documented as requested via a function are only set by the caller.
+type RepoStatus = git.RepoStatus
+
+// Reachability is whether a remote answered a connectivity check
+type Reachability = git.Reachability
+
+const (
+	ReachUnknown = git.ReachUnknown // Not checked
+	Reachable    = git.Reachable
+	Unreachable  = git.Unreachable
+)
+
+// LockedError is returned by operations that would wait on a lock file held
+// by another git process
+type LockedError = git.LockedError
+
+// Submodule is the state of a submodule, nested ones included
+type Submodule = git.Submodule
+
+// Remote is a configured git remote
+type Remote = git.Remote
+
+// RemoteBranch is a branch on a remote
+type RemoteBranch = git.RemoteBranch
+
+// Commit is a short summary of a single commit
+type Commit = git.Commit
+
+// ChangedFile is a file with uncommitted changes in the working tree
+type ChangedFile = git.ChangedFile
+
+// GetStatus computes the status of the repository at path. Problems such as
+// a missing path or a directory that isn't a repo are reported in the
+// returned status' Error field rather than as a separate error.
+func GetStatus(path, name string) *RepoStatus {
+	return git.GetStatus(path, name)
+}
+
+// GetRemoteStatus computes the status of a repository known only by its
+// remote URL. RemoteChanged is set when the remote HEAD differs from seenHead.
+func GetRemoteStatus(url, name, seenHead string) *RepoStatus {
+	return git.GetRemoteStatus(url, name, seenHead)
+}
+
+// Fetch fetches all remotes, pruning deleted remote branches
+func Fetch(path string) error {
+	return git.Fetch(path, true, nil)
+}
+
+// Pull pulls the current branch with rebase, stashing local changes around it
+func Pull(path string) error {
+	return git.Pull(path, "rebase")
+}
+
+// Push pushes the current branch to its upstream
+func Push(path string) error {
+	return git.Push(path)
+}
+
+// PushWithUpstream pushes the current branch and sets upstream tracking
+func PushWithUpstream(path, remote, branch string) error {
+	return git.PushWithUpstream(path, remote, branch)
+}
+
+// SetUpstream sets the upstream branch for the current branch
+func SetUpstream(path, remote, branch string) error {
+	return git.SetUpstream(path, remote, branch)
+}
+
+// ListRemotes returns all configured remotes, with origin first
+func ListRemotes(path string) ([]Remote, error) {
+	return git.ListRemotes(path)
+}
+
+// ListRemoteBranches returns remote branches named branchName, or all remote
+// branches when branchName is empty
+func ListRemoteBranches(path, branchName string) ([]RemoteBranch, error) {
+	return git.ListRemoteBranches(path, branchName)
+}
+
+// ChangedFiles returns files with uncommitted changes
+func ChangedFiles(path string) ([]ChangedFile, error) {
+	return git.ChangedFiles(path)
+}
+
+// RecentCommits returns up to n most recent commits on HEAD
+func RecentCommits(path string, n int) ([]Commit, error) {
+	return git.RecentCommits(path, n)
+}
+
+// DiffStat returns the number of inserted and deleted lines in staged and
+// unstaged changes combined. Untracked files are not counted.
+func DiffStat(path string) (insertions, deletions int, err error) {
+	return git.DiffStat(path)
+}
+
+// CommitCountSince returns the number of commits on HEAD committed after
+// since, which is any date git understands (e.g. midnight, 1 week ago)
+func CommitCountSince(path, since string) (int, error) {
+	return git.CommitCountSince(path, since)
+}
+
+// RemoteHost returns the host of the repo's origin remote, or of its first
+// remote when there is no origin
+func RemoteHost(path string) (string, error) {
+	return git.RemoteHost(path)
+}
+
+// CheckRemote reports whether remote answers ls-remote within a few seconds.
+// Results are reused for a few minutes.
+func CheckRemote(path, remote string) Reachability {
+	return git.CheckRemote(path, remote)
+}
+
+// SubmoduleStatus returns the submodules of the repo at path, recursively
+func SubmoduleStatus(path string) ([]Submodule, error) {
+	return git.SubmoduleStatus(path)
+}
@@ -0,0 +1,55 @@
+package gitpulse
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetStatus(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v: %s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("one\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README")
+	git("commit", "-q", "-m", "Initial commit")
+
+	status := GetStatus(dir, "repo")
+	if status.Error != nil {
+		t.Fatalf("GetStatus error: %v", status.Error)
+	}
+	if status.Branch != "main" || status.CommitSubject != "Initial commit" {
+		t.Errorf("Branch, CommitSubject = %q, %q; want main, Initial commit", status.Branch, status.CommitSubject)
+	}
+	if status.Dirty || status.HasUpstream || status.RemoteReachable != ReachUnknown {
+		t.Errorf("got %+v, want a clean repo without upstream or reachability check", status)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("two\nthree\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !GetStatus(dir, "repo").Dirty {
+		t.Error("GetStatus after an edit is not dirty")
+	}
+	if ins, del, err := DiffStat(dir); err != nil || ins != 2 || del != 1 {
+		t.Errorf("DiffStat = %d, %d, %v; want 2, 1, nil", ins, del, err)
+	}
+}
+
+func TestGetStatusNotARepo(t *testing.T) {
+	if status := GetStatus(t.TempDir(), "empty"); status.Error == nil {
+		t.Error("GetStatus of a plain directory has no Error")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/d12frosted/gitpulse/internal/ui"
+)
+
+// refreshOnSignal refreshes all repos whenever the process receives SIGUSR1,
+// e.g. from a git hook: pkill -USR1 gitpulse
+func refreshOnSignal(p *tea.Program) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+	go func() {
+		for range ch {
+			p.Send(ui.RefreshMsg{})
+		}
+	}()
+}
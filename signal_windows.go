@@ -0,0 +1,6 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// refreshOnSignal is a no-op: Windows has no SIGUSR1
+func refreshOnSignal(p *tea.Program) {}